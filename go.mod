@@ -3,7 +3,6 @@ module github.com/GeorgeTyupin/numerical_methods
 go 1.25.0
 
 require (
-	github.com/Knetic/govaluate v3.0.0+incompatible
 	github.com/go-chi/chi/v5 v5.2.5
 	github.com/ilyakaznacheev/cleanenv v1.5.0
 	gonum.org/v1/gonum v0.17.0
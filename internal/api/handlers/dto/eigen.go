@@ -0,0 +1,81 @@
+package dto
+
+import "github.com/GeorgeTyupin/numerical_methods/pkg/math"
+
+// ============================================
+// Собственные значения (задание 1)
+// ============================================
+
+type EigenStep struct {
+	Vector   []float64 `json:"vector,omitempty"`   // Приближение собственного вектора (степенной метод, обратные итерации)
+	Value    *float64  `json:"value,omitempty"`    // Приближение собственного значения
+	Residual *float64  `json:"residual,omitempty"` // ||Av - λv||₂
+	Shift    float64   `json:"shift"`              // Сдвиг, с которым выполнен шаг
+
+	Values  []float64 `json:"values,omitempty"`   // Диагональ текущей матрицы (вращения, QR)
+	OffNorm *float64  `json:"off_norm,omitempty"` // Норма внедиагональной части
+	Pivot   *[2]int   `json:"pivot,omitempty"`    // Обнуляемый элемент (p, q) в методе вращений
+}
+
+type EigenResponse struct {
+	Method     string         `json:"method"`
+	Values     []Complex      `json:"values"`            // Найденные собственные значения
+	Vectors    [][]float64    `json:"vectors,omitempty"` // vectors[k] соответствует values[k]
+	Reference  []Complex      `json:"reference"`         // Значения gonum (mat.Eigen) в порядке values
+	Deviation  *float64       `json:"deviation"`         // Наибольшее расхождение с reference
+	Iterations int            `json:"iterations"`
+	StopReason string         `json:"stop_reason"`
+	Steps      []EigenStep    `json:"steps"`
+	Info       map[string]any `json:"info,omitempty"`
+	Error      string         `json:"error,omitempty"` // Причина, по которой метод не сошелся
+}
+
+// EigenMapping конвертирует math.EigenResult в EigenResponse
+func EigenMapping(method string, res math.EigenResult) EigenResponse {
+	steps := make([]EigenStep, len(res.Steps))
+	for i, step := range res.Steps {
+		steps[i] = mapEigenStep(step)
+	}
+
+	resp := EigenResponse{
+		Method:     method,
+		Values:     ComplexMapping(res.Values),
+		Vectors:    res.Vectors,
+		Reference:  ComplexMapping(res.Reference),
+		Iterations: res.Iterations,
+		StopReason: res.StopReason,
+		Steps:      steps,
+		Info:       res.Info,
+	}
+	if res.Reference != nil {
+		resp.Deviation = Finite(res.Deviation)
+	}
+	return resp
+}
+
+func mapEigenStep(step math.EigenStep) EigenStep {
+	result := EigenStep{Shift: step.Shift}
+	if step.Vector != nil {
+		result.Vector = step.Vector
+		result.Value = Finite(step.Value)
+		result.Residual = Finite(step.Residual)
+	}
+	if step.Values != nil {
+		result.Values = step.Values
+		result.OffNorm = Finite(step.OffNorm)
+		if step.Pivot != [2]int{} {
+			pivot := step.Pivot
+			result.Pivot = &pivot
+		}
+	}
+	return result
+}
+
+// EigenMethodMapping конвертирует []math.EigenMethod в []MethodInfo
+func EigenMethodMapping(methods []math.EigenMethod) []MethodInfo {
+	result := make([]MethodInfo, len(methods))
+	for i, m := range methods {
+		result[i] = MethodInfo{Name: m.Name, Title: m.Title, Params: paramSpecMapping(m.Params)}
+	}
+	return result
+}
@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/GeorgeTyupin/numerical_methods/internal/services/jobs"
+)
+
+// Виды вычислений, которые можно запустить фоновым заданием. Тело input
+// совпадает с телом соответствующего синхронного запроса.
+const (
+	JobLinear        = "task1"                // POST /api/v1/calculate/task1/{method}
+	JobEigen         = "task1/eigen"          // POST /api/v1/calculate/task1/eigen/{method}
+	JobAnalyze       = "task1/analyze"        // POST /api/v1/calculate/task1/analyze
+	JobODE           = "task2"                // POST /api/v1/calculate/task2/{method}
+	JobSolve         = "task4"                // POST /api/v1/calculate/task4/{method}
+	JobSweep         = "task4/sweep"          // POST /api/v1/calculate/task4/sweep
+	JobAllRoots      = "task4/all_roots"      // POST /api/v1/calculate/task4/all_roots
+	JobPolyRoots     = "task4/poly_roots"     // POST /api/v1/calculate/task4/poly_roots
+	JobComplexNewton = "task4/complex_newton" // POST /api/v1/calculate/task4/complex_newton
+	JobSystem        = "task4/system"         // POST /api/v1/calculate/task4/system/{method}
+	JobBasins        = "task4/basins"         // POST /api/v1/calculate/task4/basins, результат - BasinsResponse
+)
+
+type JobRequest struct {
+	Kind   string          `json:"kind"`             // Вид вычисления (Job*)
+	Method string          `json:"method,omitempty"` // Метод для task1, task1/eigen, task2, task4 и task4/system
+	Input  json.RawMessage `json:"input"`            // Тело синхронного запроса
+}
+
+type JobProgress struct {
+	Steps int `json:"steps"`           // Сколько шагов (точек прогона, корней) уже вычислено
+	Total int `json:"total,omitempty"` // Ожидаемое число шагов, если оно известно заранее
+}
+
+type JobResponse struct {
+	ID         string      `json:"id"`
+	Kind       string      `json:"kind"`
+	Status     string      `json:"status"` // queued, running, done, failed или cancelled
+	Progress   JobProgress `json:"progress"`
+	Result     any         `json:"result,omitempty"` // Ответ синхронного запроса (при ошибке - частичный)
+	Error      string      `json:"error,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	StartedAt  *time.Time  `json:"started_at,omitempty"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty"`
+	ExpiresAt  *time.Time  `json:"expires_at,omitempty"` // Когда результат будет удален
+}
+
+// JobMapping конвертирует jobs.Snapshot в JobResponse
+func JobMapping(s jobs.Snapshot) JobResponse {
+	return JobResponse{
+		ID:         s.ID,
+		Kind:       s.Kind,
+		Status:     string(s.Status),
+		Progress:   JobProgress{Steps: s.Steps, Total: s.Total},
+		Result:     s.Result,
+		Error:      s.Err,
+		CreatedAt:  s.Created,
+		StartedAt:  optionalTime(s.Started),
+		FinishedAt: optionalTime(s.Finished),
+		ExpiresAt:  optionalTime(s.Expires),
+	}
+}
+
+// optionalTime возвращает nil для нулевого времени
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math"
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/linalg"
+)
+
+// События потока Server-Sent Events
+const (
+	EventStep        = "step"        // Шаг метода (Step, SystemStep, ComplexStep, MatrixStep, LinearIteration, EigenStep или ODEStep)
+	EventPoint       = "point"       // Точка прогона по параметру
+	EventRoot        = "root"        // Корень, найденный при сканировании отрезка
+	EventResult      = "result"      // Итоговый ответ, такой же, как у обычного запроса
+	EventInterrupted = "interrupted" // Вычисление прервано, см. InterruptedResponse
+	EventError       = "error"       // Вычисление завершилось ошибкой
+)
+
+// StreamEventMapping конвертирует значение, переданное обработчику шагов
+// math.StepFunc, в имя события и его данные. Для неизвестных значений
+// возвращается пустое имя события.
+func StreamEventMapping(step any) (string, any) {
+	switch s := step.(type) {
+	case math.Step:
+		return EventStep, mapStep(s)
+	case math.SystemStep:
+		return EventStep, mapSystemStep(s)
+	case math.ComplexStep:
+		return EventStep, mapComplexStep(s)
+	case linalg.Step:
+		return EventStep, mapMatrixStep(s)
+	case math.LinearIteration:
+		return EventStep, mapLinearIteration(s)
+	case math.EigenStep:
+		return EventStep, mapEigenStep(s)
+	case math.ODEStep:
+		return EventStep, mapODEStep(s)
+	case math.SweepPoint:
+		return EventPoint, mapSweepPoint(s)
+	case math.IsolatedRoot:
+		return EventRoot, mapIsolatedRoot(s)
+	}
+	return "", nil
+}
@@ -0,0 +1,182 @@
+package dto
+
+import (
+	stdmath "math"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math"
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/linalg"
+)
+
+// ============================================
+// Системы линейных уравнений (задание 1)
+// ============================================
+
+// Matrix - матрица по строкам
+type Matrix [][]float64
+
+type MatrixStep struct {
+	Kind  string  `json:"kind"`            // swap_rows, swap_cols, eliminate или factor
+	Row   int     `json:"row"`             // Строка ведущего элемента (с нуля)
+	Col   int     `json:"col"`             // Столбец ведущего элемента (с нуля)
+	Other *int    `json:"other,omitempty"` // С какой строкой или столбцом выполнена перестановка
+	Pivot float64 `json:"pivot"`           // Ведущий элемент шага
+	// Рабочая матрица после шага: [A|b] для Гаусса и прогонки, U для LU, Lᵀ для Холецкого
+	Matrix Matrix `json:"matrix"`
+	L      Matrix `json:"l,omitempty"` // Нижний треугольный множитель (LU, Холецкий)
+}
+
+// LinearIteration - итерация итерационного метода; по Residual строится
+// график сходимости в логарифмическом масштабе
+type LinearIteration struct {
+	X        []float64 `json:"x"`         // Приближение x_n+1
+	Residual *float64  `json:"residual"`  // ||b - Ax_n+1||₂
+	StepNorm *float64  `json:"step_norm"` // ||x_n+1 - x_n||∞
+}
+
+type LinearResponse struct {
+	Method      string         `json:"method"`
+	X           []float64      `json:"x"`
+	Determinant *float64       `json:"determinant"` // det A (null, если не вычислен)
+	Residual    *float64       `json:"residual"`    // ||Ax - b||₂
+	Steps       []MatrixStep   `json:"steps"`
+	Info        map[string]any `json:"info,omitempty"`
+
+	// Только у итерационных методов
+	Iterations int               `json:"iterations,omitempty"`
+	Trace      []LinearIteration `json:"trace,omitempty"`
+	StopReason string            `json:"stop_reason,omitempty"`
+	Error      string            `json:"error,omitempty"` // Причина, по которой итерации не сошлись
+}
+
+// LinearMapping конвертирует math.LinearResult в LinearResponse
+func LinearMapping(method string, res math.LinearResult) LinearResponse {
+	steps := make([]MatrixStep, len(res.Steps))
+	for i, step := range res.Steps {
+		steps[i] = mapMatrixStep(step)
+	}
+
+	var trace []LinearIteration
+	if res.Trace != nil {
+		trace = make([]LinearIteration, len(res.Trace))
+		for i, it := range res.Trace {
+			trace[i] = mapLinearIteration(it)
+		}
+	}
+
+	resp := LinearResponse{
+		Method:     method,
+		X:          res.X,
+		Steps:      steps,
+		Info:       res.Info,
+		Iterations: res.Iterations,
+		Trace:      trace,
+		StopReason: res.StopReason,
+	}
+	if res.X != nil {
+		// У итерационных методов определитель не вычисляется
+		if res.Trace == nil {
+			resp.Determinant = Finite(res.Det)
+		}
+		resp.Residual = Finite(res.Residual)
+	}
+	return resp
+}
+
+func mapLinearIteration(it math.LinearIteration) LinearIteration {
+	return LinearIteration{
+		X:        it.X,
+		Residual: Finite(it.Residual),
+		StepNorm: Finite(it.StepNorm),
+	}
+}
+
+func mapMatrixStep(step linalg.Step) MatrixStep {
+	result := MatrixStep{
+		Kind:   step.Kind,
+		Row:    step.Row,
+		Col:    step.Col,
+		Pivot:  step.Pivot,
+		Matrix: step.Matrix,
+		L:      step.L,
+	}
+	if step.Kind == linalg.StepSwapRows || step.Kind == linalg.StepSwapCols {
+		other := step.Other
+		result.Other = &other
+	}
+	return result
+}
+
+// ============================================
+// Анализ обусловленности матрицы
+// ============================================
+
+type PerturbationResponse struct {
+	Direction     string    `json:"direction"` // worst - худшее направление, user - задано в запросе
+	B             []float64 `json:"b"`
+	DB            []float64 `json:"db"`
+	X             []float64 `json:"x"`           // Решение Ax = b
+	XPerturbed    []float64 `json:"x_perturbed"` // Решение A(x + δx) = b + δb
+	RelDB         *float64  `json:"rel_db"`      // ||δb||₂ / ||b||₂
+	RelDX         *float64  `json:"rel_dx"`      // ||δx||₂ / ||x||₂
+	Amplification *float64  `json:"amplification"`
+	Bound         *float64  `json:"bound"` // cond₂(A) · rel_db - оценка сверху для rel_dx
+}
+
+type MatrixAnalysisResponse struct {
+	Matrix         Matrix                `json:"matrix"`
+	Preset         string                `json:"preset,omitempty"`
+	Size           int                   `json:"size"`
+	Determinant    *float64              `json:"determinant"`
+	Inverse        Matrix                `json:"inverse"` // null, если матрица вырождена
+	Rank           int                   `json:"rank"`
+	RankTolerance  float64               `json:"rank_tolerance"` // Сингулярные числа не больше порога считаются нулевыми
+	SingularValues []float64             `json:"singular_values"`
+	Cond1          *float64              `json:"cond_1"` // null - матрица вырождена (cond = ∞)
+	Cond2          *float64              `json:"cond_2"`
+	CondInf        *float64              `json:"cond_inf"`
+	LostDigits     *float64              `json:"lost_digits"` // log₁₀ cond₂: сколько верных десятичных знаков теряет решение
+	Singular       bool                  `json:"singular"`
+	Perturbation   *PerturbationResponse `json:"perturbation"`
+}
+
+// MatrixAnalysisMapping конвертирует math.MatrixAnalysis в MatrixAnalysisResponse
+func MatrixAnalysisMapping(res math.MatrixAnalysis) MatrixAnalysisResponse {
+	resp := MatrixAnalysisResponse{
+		Matrix:         res.Matrix,
+		Preset:         res.Preset,
+		Size:           len(res.Matrix),
+		Determinant:    Finite(res.Det),
+		Inverse:        res.Inverse,
+		Rank:           res.Rank,
+		RankTolerance:  res.RankTol,
+		SingularValues: res.Singular,
+		Cond1:          Finite(res.Cond1),
+		Cond2:          Finite(res.Cond2),
+		CondInf:        Finite(res.CondInf),
+		LostDigits:     Finite(stdmath.Log10(res.Cond2)),
+		Singular:       res.Inverse == nil,
+	}
+	if p := res.Perturbation; p != nil {
+		resp.Perturbation = &PerturbationResponse{
+			Direction:     p.Direction,
+			B:             p.B,
+			DB:            p.DB,
+			X:             p.X,
+			XPerturbed:    p.XPerturbed,
+			RelDB:         Finite(p.RelDB),
+			RelDX:         Finite(p.RelDX),
+			Amplification: Finite(p.Amplification),
+			Bound:         Finite(p.Bound),
+		}
+	}
+	return resp
+}
+
+// LinearMethodMapping конвертирует []math.LinearMethod в []MethodInfo
+func LinearMethodMapping(methods []math.LinearMethod) []MethodInfo {
+	result := make([]MethodInfo, len(methods))
+	for i, m := range methods {
+		result[i] = MethodInfo{Name: m.Name, Title: m.Title, Params: paramSpecMapping(m.Params)}
+	}
+	return result
+}
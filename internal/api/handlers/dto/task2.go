@@ -0,0 +1,57 @@
+package dto
+
+import "github.com/GeorgeTyupin/numerical_methods/pkg/math"
+
+// ============================================
+// Задача Коши для ОДУ (задание 2)
+// ============================================
+
+type ODEStep struct {
+	X     float64  `json:"x"`
+	Y     *float64 `json:"y"`     // Численное решение y_i
+	F     *float64 `json:"f"`     // f(x_i, y_i)
+	Exact *float64 `json:"exact"` // Точное решение (null, если не задано)
+	Error *float64 `json:"error"` // Глобальная ошибка |y(x_i) - y_i| (null без точного решения)
+}
+
+type ODEResponse struct {
+	Method   string         `json:"method"`
+	Table    []ODEStep      `json:"table"`
+	MaxError *float64       `json:"max_error"` // Наибольшая глобальная ошибка (null без точного решения)
+	Info     map[string]any `json:"info,omitempty"`
+	Error    string         `json:"error,omitempty"` // Причина, по которой решение оборвалось
+}
+
+// ODEMapping конвертирует math.ODEResult в ODEResponse
+func ODEMapping(method string, res math.ODEResult) ODEResponse {
+	table := make([]ODEStep, len(res.Steps))
+	for i, step := range res.Steps {
+		table[i] = mapODEStep(step)
+	}
+
+	return ODEResponse{
+		Method:   method,
+		Table:    table,
+		MaxError: Finite(res.MaxError),
+		Info:     res.Info,
+	}
+}
+
+func mapODEStep(step math.ODEStep) ODEStep {
+	return ODEStep{
+		X:     step.X,
+		Y:     Finite(step.Y),
+		F:     Finite(step.F),
+		Exact: Finite(step.Exact),
+		Error: Finite(step.Error),
+	}
+}
+
+// ODEMethodMapping конвертирует []math.ODEMethod в []MethodInfo
+func ODEMethodMapping(methods []math.ODEMethod) []MethodInfo {
+	result := make([]MethodInfo, len(methods))
+	for i, m := range methods {
+		result[i] = MethodInfo{Name: m.Name, Title: m.Title, Params: paramSpecMapping(m.Params)}
+	}
+	return result
+}
@@ -1,113 +1,430 @@
 package dto
 
-import "github.com/GeorgeTyupin/numerical_methods/pkg/math"
+import (
+	stdmath "math"
 
-// BaseRequest содержит общие поля для всех методов поиска корней
-type BaseRequest struct {
-	Formula string  `json:"formula"` // Функция, например "x^3 - 2*x - 5"
-	Epsilon float64 `json:"epsilon"` // Требуемая точность
-}
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math"
+)
+
+// CalculateRequest - тело запроса к любому методу из реестра.
+// Набор полей определяется схемой метода: formula, epsilon, x0, a, b и т.д.
+type CalculateRequest map[string]any
 
 // BaseResponse содержит общие поля ответа для графиков
 type BaseResponse struct {
-	Root       float64 `json:"root"`       // Найденный корень уравнения
-	Iterations int     `json:"iterations"` // Затраченное количество итераций
-	Error      float64 `json:"error"`      // Итоговая погрешность
+	Root       float64  `json:"root"`       // Найденный корень уравнения
+	Iterations int      `json:"iterations"` // Затраченное количество итераций
+	Error      *float64 `json:"error"`      // Оценка погрешности корня (null, если ее не удалось получить)
+}
+
+// Finite возвращает указатель на число или nil для NaN и бесконечностей,
+// которые нельзя передать в JSON
+func Finite(v float64) *float64 {
+	if stdmath.IsNaN(v) || stdmath.IsInf(v, 0) {
+		return nil
+	}
+	return &v
+}
+
+// InterruptedResponse - ответ на вычисление, прерванное отменой запроса,
+// по времени или по бюджету вычислений функции
+type InterruptedResponse struct {
+	Error   string `json:"error"`
+	Status  string `json:"status"`            // cancelled, timeout или eval_budget
+	Partial any    `json:"partial,omitempty"` // Результат, полученный до прерывания
 }
 
 // ============================================
-// Метод Дихотомии (Dichotomy)
+// Унифицированный шаг и ответ
 // ============================================
 
-type DichotomyRequest struct {
-	BaseRequest
-	A float64 `json:"a"` // Левая граница
-	B float64 `json:"b"` // Правая граница
+type Step struct {
+	XPrev float64 `json:"x_prev"` // Текущий x_n
+	XNew  float64 `json:"x_new"`  // Вычисленный x_n+1
+	Fx    float64 `json:"fx"`     // Значение функции на шаге
+
+	// Отрезок заполняется только интервальными методами и методами хорд
+	A *float64 `json:"a,omitempty"` // Левая граница отрезка (или первый конец хорды) на текущем шаге
+	B *float64 `json:"b,omitempty"` // Правая граница отрезка (или второй конец хорды) на текущем шаге
+	C *float64 `json:"c,omitempty"` // Точка деления отрезка (пересечение хорды с осью) на текущем шаге
+
+	Kind string `json:"kind,omitempty"` // Тип подшага комбинированного метода
+
+	// Диагностика шага, если она была вычислена
+	Residual   *float64 `json:"residual,omitempty"`    // Невязка |f(x_n+1)|
+	StepSize   *float64 `json:"step_size,omitempty"`   // |x_n+1 - x_n|
+	ErrorBound *float64 `json:"error_bound,omitempty"` // Апостериорная оценка погрешности
+	Order      *float64 `json:"order,omitempty"`       // Оценка порядка сходимости на шаге
 }
 
-type DichotomyStep struct {
-	A float64 `json:"a"` // Левая граница отрезка на текущем шаге
-	B float64 `json:"b"` // Правая граница отрезка на текущем шаге
-	C float64 `json:"c"` // Середина отрезка на текущем шаге
+// StepMapping конвертирует шаги math.Result в []Step вместе с их диагностикой
+func StepMapping(res math.Result) []Step {
+	result := make([]Step, len(res.Steps))
+	for i, step := range res.Steps {
+		result[i] = mapStep(step)
+		if i < len(res.Diagnostics.Steps) {
+			d := res.Diagnostics.Steps[i]
+			result[i].Residual = Finite(d.Residual)
+			result[i].StepSize = Finite(d.StepSize)
+			result[i].ErrorBound = Finite(d.ErrorBound)
+			result[i].Order = Finite(d.Order)
+		}
+	}
+	return result
 }
 
-type DichotomyResponse struct {
+// mapStep конвертирует math.Step в Step без диагностики
+func mapStep(step math.Step) Step {
+	result := Step{
+		XPrev: step.XPrev,
+		XNew:  step.XNew,
+		Fx:    step.Fx,
+		Kind:  step.Kind,
+	}
+	if step.Segment {
+		a, b, c := step.A, step.B, step.XNew
+		result.A, result.B, result.C = &a, &b, &c
+	}
+	return result
+}
+
+// Diagnostics - эмпирическая скорость сходимости: e_n+1 ≈ C·e_n^p
+type Diagnostics struct {
+	Order     *float64 `json:"order"`      // Порядок сходимости p
+	Rate      *float64 `json:"rate"`       // Асимптотическая константа C
+	BoundKind string   `json:"bound_kind"` // Способ оценки погрешности: bracket, contraction или newton
+}
+
+// DiagnosticsMapping конвертирует math.Diagnostics в Diagnostics
+func DiagnosticsMapping(d math.Diagnostics) Diagnostics {
+	return Diagnostics{Order: Finite(d.Order), Rate: Finite(d.Rate), BoundKind: d.BoundKind}
+}
+
+type CalculateResponse struct {
 	BaseResponse
-	Steps []DichotomyStep `json:"steps"`
+	Method      string         `json:"method"`
+	Steps       []Step         `json:"steps"`
+	RawSteps    []Step         `json:"raw_steps,omitempty"` // Шаги без ускорения сходимости, если оно применялось
+	StopReason  string         `json:"stop_reason"`         // Критерий, по которому остановились итерации
+	Diagnostics Diagnostics    `json:"diagnostics"`
+	Info        map[string]any `json:"info,omitempty"` // Дополнительные сведения метода
 }
 
-func DichotomyStepMapping(steps []math.DichotomyStep) []DichotomyStep {
-	dichotomySteps := make([]DichotomyStep, len(steps))
-	for i, step := range steps {
-		dichotomySteps[i] = DichotomyStep{
-			A: step.A,
-			B: step.B,
-		}
+// ============================================
+// Прогон по параметру (Sweep)
+// ============================================
+
+type SweepRequest struct {
+	Method       string           `json:"method"`       // Имя метода из реестра
+	Param        string           `json:"param"`        // Имя изменяемого параметра формулы
+	From         float64          `json:"from"`         // Начальное значение параметра
+	To           float64          `json:"to"`           // Конечное значение параметра
+	Points       int              `json:"points"`       // Количество точек сетки
+	Continuation bool             `json:"continuation"` // Брать предыдущий корень как x0 следующей точки
+	Input        CalculateRequest `json:"input"`        // Параметры метода, как в обычном запросе
+}
+
+type SweepPoint struct {
+	Value      float64  `json:"value"`           // Значение параметра
+	Root       *float64 `json:"root"`            // Корень или null, если метод не сошелся
+	Iterations int      `json:"iterations"`      // Затраченное количество итераций
+	Error      string   `json:"error,omitempty"` // Причина, по которой корень не найден
+}
+
+type SweepResponse struct {
+	Method string       `json:"method"`
+	Param  string       `json:"param"`
+	Points []SweepPoint `json:"points"`
+}
+
+// SweepPointMapping конвертирует []math.SweepPoint в []SweepPoint
+func SweepPointMapping(points []math.SweepPoint) []SweepPoint {
+	result := make([]SweepPoint, len(points))
+	for i, point := range points {
+		result[i] = mapSweepPoint(point)
 	}
-	return dichotomySteps
+	return result
+}
+
+func mapSweepPoint(point math.SweepPoint) SweepPoint {
+	result := SweepPoint{
+		Value:      point.Value,
+		Iterations: point.Iterations,
+	}
+	if point.Err != nil {
+		result.Error = point.Err.Error()
+	} else {
+		root := point.Root
+		result.Root = &root
+	}
+	return result
 }
 
 // ============================================
-// Метод Ньютона (Newton)
+// Поиск всех корней на отрезке (AllRoots)
 // ============================================
 
-type NewtonRequest struct {
-	BaseRequest
-	X0 float64 `json:"x0"` // Начальное приближение
+type AllRootsRequest struct {
+	Method  string           `json:"method"`  // Метод уточнения корней из реестра
+	A       float64          `json:"a"`       // Левая граница отрезка сканирования
+	B       float64          `json:"b"`       // Правая граница отрезка сканирования
+	Samples int              `json:"samples"` // Число точек начальной сетки (0 - по умолчанию)
+	Input   CalculateRequest `json:"input"`   // formula, epsilon, params и параметры метода
 }
 
-type NewtonStep struct {
-	XPrev float64 `json:"x_prev"` // Текущий x_n
-	XNew  float64 `json:"x_new"`  // Вычисленный x_n+1
-	Fx    float64 `json:"fx"`     // Значение f(x_prev)
+type IsolatedRoot struct {
+	Root       *float64       `json:"root"`             // Корень или null, если уточнение не удалось
+	A          float64        `json:"a"`                // Левая граница отрезка локализации
+	B          float64        `json:"b"`                // Правая граница отрезка локализации
+	Kind       string         `json:"kind"`             // sign_change, tangent или exact
+	Method     string         `json:"method,omitempty"` // Метод, которым уточнялся корень
+	Iterations int            `json:"iterations"`
+	Steps      []Step         `json:"steps"`
+	Info       map[string]any `json:"info,omitempty"`
+	Error      string         `json:"error,omitempty"` // Причина, по которой корень не уточнен
 }
 
-// NewtonStepMapping конвертирует []math.NewtonStep в []NewtonStep
-func NewtonStepMapping(steps []math.NewtonStep) []NewtonStep {
-	newtonSteps := make([]NewtonStep, len(steps))
-	for i, step := range steps {
-		newtonSteps[i] = NewtonStep{
-			XPrev: step.XPrev,
-			XNew:  step.XNew,
-			Fx:    step.Fx,
-		}
+type Singularity struct {
+	X    float64 `json:"x"`
+	Kind string  `json:"kind"` // pole или jump
+}
+
+type AllRootsResponse struct {
+	Method        string         `json:"method"`
+	Roots         []IsolatedRoot `json:"roots"`
+	Singularities []Singularity  `json:"singularities"`
+	Evaluations   int            `json:"evaluations"` // Число вычислений функции при сканировании
+}
+
+// AllRootsMapping конвертирует math.RootScan в AllRootsResponse
+func AllRootsMapping(method string, scan math.RootScan) AllRootsResponse {
+	resp := AllRootsResponse{
+		Method:        method,
+		Roots:         make([]IsolatedRoot, len(scan.Roots)),
+		Singularities: make([]Singularity, len(scan.Singularities)),
+		Evaluations:   scan.Evaluations,
 	}
-	return newtonSteps
+
+	for i, root := range scan.Roots {
+		resp.Roots[i] = mapIsolatedRoot(root)
+	}
+	for i, sg := range scan.Singularities {
+		resp.Singularities[i] = Singularity{X: sg.X, Kind: sg.Kind}
+	}
+
+	return resp
 }
 
-type NewtonResponse struct {
-	BaseResponse
-	Steps []NewtonStep `json:"steps"`
+func mapIsolatedRoot(root math.IsolatedRoot) IsolatedRoot {
+	result := IsolatedRoot{
+		A:          root.A,
+		B:          root.B,
+		Kind:       root.Kind,
+		Method:     root.Method,
+		Iterations: root.Result.Iterations,
+		Steps:      StepMapping(root.Result),
+		Info:       root.Result.Info,
+	}
+	if root.Err != nil {
+		result.Error = root.Err.Error()
+	} else {
+		x := root.Result.Root
+		result.Root = &x
+	}
+	return result
 }
 
 // ============================================
-// Метод Простой Итерации (Simple Iteration)
+// Все корни многочлена (PolyRoots)
 // ============================================
 
-type SimpleIterRequest struct {
-	BaseRequest
-	X0 float64 `json:"x0"` // Начальное приближение
+// Complex - комплексное число в виде пары вещественная/мнимая часть
+type Complex struct {
+	Re float64 `json:"re"`
+	Im float64 `json:"im"`
 }
 
-type SimpleIterStep struct {
-	XPrev float64 `json:"x_prev"` // Текущий x_n
-	XNew  float64 `json:"x_new"`  // Вычисленный x_n+1 (результат phi(x))
-	Fx    float64 `json:"fx"`     // Значение функции (можно передавать, если нужно для построения на графике, или опустить)
+type PolyRootsResponse struct {
+	Coefficients []float64   `json:"coefficients"` // Коэффициенты от старшей степени к свободному члену
+	Degree       int         `json:"degree"`
+	Roots        []Complex   `json:"roots"`       // Корни, найденные методом Аберта - Эрлиха
+	Eigenvalues  []Complex   `json:"eigenvalues"` // Собственные значения сопровождающей матрицы в порядке корней
+	Deviation    float64     `json:"deviation"`   // Наибольшее расхождение корней и собственных значений
+	Iterations   int         `json:"iterations"`
+	Trace        [][]Complex `json:"trace"`           // Приближения ко всем корням на каждой итерации
+	Error        string      `json:"error,omitempty"` // Причина, по которой итерации не сошлись
 }
 
-func SimpleIterStepMapping(steps []math.SimpleIterStep) []SimpleIterStep {
-	simpleIterSteps := make([]SimpleIterStep, len(steps))
-	for i, step := range steps {
-		simpleIterSteps[i] = SimpleIterStep{
-			XPrev: step.XPrev,
-			XNew:  step.XNew,
-			Fx:    step.Fx,
-		}
+// ComplexMapping конвертирует []complex128 в []Complex
+func ComplexMapping(z []complex128) []Complex {
+	result := make([]Complex, len(z))
+	for i, v := range z {
+		result[i] = Complex{Re: real(v), Im: imag(v)}
 	}
-	return simpleIterSteps
+	return result
 }
 
-type SimpleIterResponse struct {
-	BaseResponse
-	Steps []SimpleIterStep `json:"steps"`
+// PolyRootsMapping конвертирует math.PolyRoots в PolyRootsResponse
+func PolyRootsMapping(roots math.PolyRoots) PolyRootsResponse {
+	n := len(roots.Coeffs)
+	coeffs := make([]float64, n)
+	for i, c := range roots.Coeffs {
+		coeffs[n-1-i] = c
+	}
+
+	trace := make([][]Complex, len(roots.Trace))
+	for i, z := range roots.Trace {
+		trace[i] = ComplexMapping(z)
+	}
+
+	return PolyRootsResponse{
+		Coefficients: coeffs,
+		Degree:       n - 1,
+		Roots:        ComplexMapping(roots.Roots),
+		Eigenvalues:  ComplexMapping(roots.Eigen),
+		Deviation:    roots.Deviation,
+		Iterations:   roots.Iterations,
+		Trace:        trace,
+	}
+}
+
+// ============================================
+// Комплексный метод Ньютона
+// ============================================
+
+type ComplexStep struct {
+	ZPrev Complex `json:"z_prev"` // Текущее приближение z_n
+	ZNew  Complex `json:"z_new"`  // Следующее приближение z_n+1
+	Fz    Complex `json:"fz"`     // f(z_n)
+}
+
+type ComplexNewtonResponse struct {
+	Root       Complex        `json:"root"`
+	Iterations int            `json:"iterations"`
+	StopReason string         `json:"stop_reason,omitempty"` // Критерий, по которому остановились итерации
+	Steps      []ComplexStep  `json:"steps"`
+	Info       map[string]any `json:"info,omitempty"`
+	Error      string         `json:"error,omitempty"` // Причина, по которой метод не сошелся
+}
+
+// ComplexNewtonMapping конвертирует math.ComplexResult в ComplexNewtonResponse
+func ComplexNewtonMapping(res math.ComplexResult) ComplexNewtonResponse {
+	steps := make([]ComplexStep, len(res.Steps))
+	for i, step := range res.Steps {
+		steps[i] = mapComplexStep(step)
+	}
+
+	return ComplexNewtonResponse{
+		Root:       Complex{Re: real(res.Root), Im: imag(res.Root)},
+		Iterations: res.Iterations,
+		StopReason: res.StopReason,
+		Steps:      steps,
+		Info:       res.Info,
+	}
+}
+
+func mapComplexStep(step math.ComplexStep) ComplexStep {
+	return ComplexStep{
+		ZPrev: Complex{Re: real(step.ZPrev), Im: imag(step.ZPrev)},
+		ZNew:  Complex{Re: real(step.ZNew), Im: imag(step.ZNew)},
+		Fz:    Complex{Re: real(step.Fz), Im: imag(step.Fz)},
+	}
+}
+
+// BasinsResponse - результат фонового задания task4/basins. Синхронный запрос
+// отдает PNG как есть, а в JSON задания изображение передается data URL.
+type BasinsResponse struct {
+	Image string `json:"image"` // data:image/png;base64,...
+}
+
+// ============================================
+// Системы нелинейных уравнений
+// ============================================
+
+type SystemStep struct {
+	XPrev    []float64 `json:"x_prev"`    // Текущее приближение x_n
+	XNew     []float64 `json:"x_new"`     // Следующее приближение x_n+1
+	Residual float64   `json:"residual"`  // Норма невязки ||F(x_n+1)||
+	StepNorm float64   `json:"step_norm"` // Норма шага ||x_n+1 - x_n||
+}
+
+type SystemResponse struct {
+	Method     string         `json:"method"`
+	Root       []float64      `json:"root"`
+	Iterations int            `json:"iterations"`
+	StopReason string         `json:"stop_reason,omitempty"` // Критерий, по которому остановились итерации
+	Steps      []SystemStep   `json:"steps"`
+	Info       map[string]any `json:"info,omitempty"`
+	Error      string         `json:"error,omitempty"` // Причина, по которой метод не сошелся
+}
+
+// SystemMapping конвертирует math.SystemResult в SystemResponse
+func SystemMapping(method string, res math.SystemResult) SystemResponse {
+	steps := make([]SystemStep, len(res.Steps))
+	for i, step := range res.Steps {
+		steps[i] = mapSystemStep(step)
+	}
+
+	return SystemResponse{
+		Method:     method,
+		Root:       res.Root,
+		Iterations: res.Iterations,
+		StopReason: res.StopReason,
+		Steps:      steps,
+		Info:       res.Info,
+	}
+}
+
+func mapSystemStep(step math.SystemStep) SystemStep {
+	return SystemStep{XPrev: step.XPrev, XNew: step.XNew, Residual: step.Residual, StepNorm: step.StepNorm}
+}
+
+// ============================================
+// Описание методов (схемы параметров)
+// ============================================
+
+type ParamSpec struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required"`
+	Default     any    `json:"default,omitempty"`
+	Description string `json:"description"`
+}
+
+type MethodInfo struct {
+	Name   string      `json:"name"`
+	Title  string      `json:"title"`
+	Params []ParamSpec `json:"params"`
+}
+
+// MethodMapping конвертирует []math.Method в []MethodInfo
+func MethodMapping(methods []math.Method) []MethodInfo {
+	result := make([]MethodInfo, len(methods))
+	for i, m := range methods {
+		result[i] = MethodInfo{Name: m.Name, Title: m.Title, Params: paramSpecMapping(m.Params)}
+	}
+	return result
+}
+
+// SystemMethodMapping конвертирует []math.SystemMethod в []MethodInfo
+func SystemMethodMapping(methods []math.SystemMethod) []MethodInfo {
+	result := make([]MethodInfo, len(methods))
+	for i, m := range methods {
+		result[i] = MethodInfo{Name: m.Name, Title: m.Title, Params: paramSpecMapping(m.Params)}
+	}
+	return result
+}
+
+func paramSpecMapping(specs []math.ParamSpec) []ParamSpec {
+	params := make([]ParamSpec, len(specs))
+	for j, p := range specs {
+		params[j] = ParamSpec{
+			Name:        p.Name,
+			Type:        string(p.Type),
+			Required:    p.Required,
+			Default:     p.Default,
+			Description: p.Description,
+		}
+	}
+	return params
 }
@@ -1,6 +1,7 @@
 package handutils
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
 
@@ -12,9 +13,17 @@ func RespondWithError(w http.ResponseWriter, code int, message string) {
 	RespondWithJSON(w, code, errs.HTTPError{Error: message})
 }
 
-// RespondWithJSON отправляет HTTP-ответ с данными в формате JSON
+// RespondWithJSON отправляет HTTP-ответ с данными в формате JSON.
+// Ответ сначала кодируется в буфер: если payload не кодируется (например,
+// содержит NaN или Inf), клиент получает 500, а не обрезанное тело с кодом code.
 func RespondWithJSON(w http.ResponseWriter, code int, payload any) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(payload); err != nil {
+		buf.Reset()
+		code = http.StatusInternalServerError
+		json.NewEncoder(&buf).Encode(errs.HTTPError{Error: "не удалось сформировать ответ: " + err.Error()})
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
-	json.NewEncoder(w).Encode(payload)
+	w.Write(buf.Bytes())
 }
@@ -0,0 +1,48 @@
+package handutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// EventStream отправляет ответ в формате Server-Sent Events. Заголовки
+// пишутся при первом событии, поэтому до него ошибку можно вернуть обычным
+// JSON-ответом с подходящим кодом.
+type EventStream struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	started bool
+}
+
+func NewEventStream(w http.ResponseWriter) *EventStream {
+	return &EventStream{w: w, rc: http.NewResponseController(w)}
+}
+
+// Started сообщает, было ли уже отправлено хотя бы одно событие
+func (s *EventStream) Started() bool {
+	return s.started
+}
+
+// Send отправляет событие с данными в формате JSON и сразу сбрасывает буфер
+func (s *EventStream) Send(event string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	if !s.started {
+		h := s.w.Header()
+		h.Set("Content-Type", "text/event-stream")
+		h.Set("Cache-Control", "no-cache")
+		h.Set("Connection", "keep-alive")
+		h.Set("X-Accel-Buffering", "no") // Отключаем буферизацию в nginx
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
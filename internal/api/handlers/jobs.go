@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/GeorgeTyupin/numerical_methods/internal/api/handlers/dto"
+	"github.com/GeorgeTyupin/numerical_methods/internal/api/handlers/handutils"
+	errs "github.com/GeorgeTyupin/numerical_methods/internal/errors"
+	"github.com/GeorgeTyupin/numerical_methods/internal/services/jobs"
+	"github.com/go-chi/chi/v5"
+)
+
+const jobsComponent = "jobs_handler"
+
+type JobsHandler struct {
+	logger *slog.Logger
+	jobs   *jobs.Manager
+	task1  *Task1Handler
+	task2  *Task2Handler
+	task4  *Task4Handler
+}
+
+func NewJobsHandler(logger *slog.Logger, manager *jobs.Manager, task1 *Task1Handler, task2 *Task2Handler, task4 *Task4Handler) *JobsHandler {
+	logger = logger.With(slog.String("component", jobsComponent))
+	return &JobsHandler{logger: logger, jobs: manager, task1: task1, task2: task2, task4: task4}
+}
+
+// Submit ставит вычисление в очередь и возвращает идентификатор задания
+func (h *JobsHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	var req dto.JobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	run, total, err := h.jobRun(req)
+	if err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	job, err := h.jobs.Submit(req.Kind, jobs.Task(run), total)
+	if errors.Is(err, jobs.ErrQueueFull) || errors.Is(err, jobs.ErrClosed) {
+		handutils.RespondWithError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to submit job", slog.Any("error", err))
+		handutils.RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/jobs/"+job.ID)
+	handutils.RespondWithJSON(w, http.StatusAccepted, dto.JobMapping(job))
+}
+
+// Get возвращает состояние, прогресс и результат задания
+func (h *JobsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobs.Get(chi.URLParam(r, "id"))
+	if err != nil {
+		handutils.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	handutils.RespondWithJSON(w, http.StatusOK, dto.JobMapping(job))
+}
+
+// Cancel отменяет задание
+func (h *JobsHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobs.Cancel(chi.URLParam(r, "id"))
+	if err != nil {
+		handutils.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	handutils.RespondWithJSON(w, http.StatusOK, dto.JobMapping(job))
+}
+
+// jobRun разбирает input задания по его виду и возвращает вычисление вместе
+// с ожидаемым числом шагов (0, если оно неизвестно заранее)
+func (h *JobsHandler) jobRun(req dto.JobRequest) (runFunc, int, error) {
+	decode := func(v any) error {
+		if err := json.Unmarshal(req.Input, v); err != nil {
+			return fmt.Errorf("%w: input", errs.ErrInvalidJSON)
+		}
+		return nil
+	}
+
+	switch req.Kind {
+	case dto.JobLinear, dto.JobEigen, dto.JobODE, dto.JobSolve, dto.JobSystem:
+		if req.Method == "" {
+			return nil, 0, fmt.Errorf("для задания %q нужно указать method", req.Kind)
+		}
+		var input dto.CalculateRequest
+		if err := decode(&input); err != nil {
+			return nil, 0, err
+		}
+		switch req.Kind {
+		case dto.JobLinear:
+			return h.task1.solveRun(req.Method, input), 0, nil
+		case dto.JobEigen:
+			return h.task1.eigenRun(req.Method, input), 0, nil
+		case dto.JobODE:
+			return h.task2.solveRun(req.Method, input), 0, nil
+		case dto.JobSystem:
+			return h.task4.systemRun(req.Method, input), 0, nil
+		}
+		return h.task4.solveRun(req.Method, input), 0, nil
+	case dto.JobSweep:
+		var input dto.SweepRequest
+		if err := decode(&input); err != nil {
+			return nil, 0, err
+		}
+		return h.task4.sweepRun(input), input.Points, nil
+	case dto.JobAllRoots:
+		var input dto.AllRootsRequest
+		if err := decode(&input); err != nil {
+			return nil, 0, err
+		}
+		return h.task4.allRootsRun(input), 0, nil
+	case dto.JobAnalyze, dto.JobPolyRoots, dto.JobComplexNewton, dto.JobBasins:
+		var input dto.CalculateRequest
+		if err := decode(&input); err != nil {
+			return nil, 0, err
+		}
+		switch req.Kind {
+		case dto.JobAnalyze:
+			return h.task1.analyzeRun(input), 0, nil
+		case dto.JobPolyRoots:
+			return h.task4.polyRootsRun(input), 0, nil
+		case dto.JobBasins:
+			return h.task4.basinsRun(input), 0, nil
+		}
+		return h.task4.complexNewtonRun(input), 0, nil
+	}
+	return nil, 0, fmt.Errorf("неизвестный вид задания %q", req.Kind)
+}
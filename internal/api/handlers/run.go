@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"image/png"
+
+	"github.com/GeorgeTyupin/numerical_methods/internal/api/handlers/dto"
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math"
+)
+
+// runFunc - вычисление по уже разобранному запросу. Возвращает тело ответа
+// (при прерывании - частичный результат) и ошибку. Одни и те же вычисления
+// запускаются потоковыми запросами и фоновыми заданиями.
+type runFunc func(ctx context.Context) (any, error)
+
+func (h *Task4Handler) solveRun(method string, req dto.CalculateRequest) runFunc {
+	return func(ctx context.Context) (any, error) {
+		res, err := h.engine.Solve(ctx, method, math.Params(req))
+		return calculateResponse(method, res), err
+	}
+}
+
+func (h *Task4Handler) sweepRun(req dto.SweepRequest) runFunc {
+	sweepRange := math.SweepRange{Param: req.Param, From: req.From, To: req.To, Points: req.Points}
+	return func(ctx context.Context) (any, error) {
+		points, err := h.engine.Sweep(ctx, req.Method, math.Params(req.Input), sweepRange, req.Continuation)
+		return dto.SweepResponse{Method: req.Method, Param: req.Param, Points: dto.SweepPointMapping(points)}, err
+	}
+}
+
+func (h *Task4Handler) allRootsRun(req dto.AllRootsRequest) runFunc {
+	scanRange := math.ScanRange{A: req.A, B: req.B, Samples: req.Samples}
+	return func(ctx context.Context) (any, error) {
+		scan, err := h.engine.AllRoots(ctx, req.Method, math.Params(req.Input), scanRange)
+		return dto.AllRootsMapping(req.Method, scan), err
+	}
+}
+
+func (h *Task4Handler) polyRootsRun(req dto.CalculateRequest) runFunc {
+	return func(ctx context.Context) (any, error) {
+		roots, err := h.engine.PolynomialRoots(ctx, math.Params(req))
+		resp := dto.PolyRootsMapping(roots)
+		if err != nil && len(roots.Roots) > 0 && !isInterrupted(err) {
+			resp.Error, err = err.Error(), nil
+		}
+		return resp, err
+	}
+}
+
+func (h *Task4Handler) complexNewtonRun(req dto.CalculateRequest) runFunc {
+	return func(ctx context.Context) (any, error) {
+		res, err := h.engine.ComplexNewton(ctx, math.Params(req))
+		resp := dto.ComplexNewtonMapping(res)
+		// Как и в обычном запросе, несошедшийся метод - это результат с траекторией
+		if err != nil && len(res.Steps) > 0 && !isInterrupted(err) {
+			resp.Error, err = err.Error(), nil
+		}
+		return resp, err
+	}
+}
+
+func (h *Task4Handler) systemRun(method string, req dto.CalculateRequest) runFunc {
+	return func(ctx context.Context) (any, error) {
+		res, err := h.engine.SolveSystem(ctx, method, math.Params(req))
+		resp := dto.SystemMapping(method, res)
+		if err != nil && len(res.Steps) > 0 && !isInterrupted(err) {
+			resp.Error, err = err.Error(), nil
+		}
+		return resp, err
+	}
+}
+
+func (h *Task4Handler) basinsRun(req dto.CalculateRequest) runFunc {
+	return func(ctx context.Context) (any, error) {
+		img, err := h.engine.Basins(ctx, math.Params(req))
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+		return dto.BasinsResponse{Image: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())}, nil
+	}
+}
+
+// isInterrupted проверяет, что вычисление было прервано, а не завершилось ошибкой метода
+func isInterrupted(err error) bool {
+	var ie *math.InterruptedError
+	return errors.As(err, &ie)
+}
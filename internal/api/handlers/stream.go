@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/GeorgeTyupin/numerical_methods/internal/api/handlers/dto"
+	"github.com/GeorgeTyupin/numerical_methods/internal/api/handlers/handutils"
+	errs "github.com/GeorgeTyupin/numerical_methods/internal/errors"
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math"
+	"github.com/go-chi/chi/v5"
+)
+
+// Потоковые версии запросов к заданию 4: каждый шаг отправляется событием
+// Server-Sent Events сразу после вычисления, а в конце приходит событие
+// result с тем же телом, что и у обычного запроса.
+
+// StreamCalculate запускает метод из реестра с потоковой передачей шагов
+func (h *Task4Handler) StreamCalculate(w http.ResponseWriter, r *http.Request) {
+	method := chi.URLParam(r, "method")
+
+	var req dto.CalculateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	streamRun(w, r, h.solveRun(method, req))
+}
+
+// StreamSweep выполняет прогон по параметру, отправляя каждую точку отдельным событием
+func (h *Task4Handler) StreamSweep(w http.ResponseWriter, r *http.Request) {
+	var req dto.SweepRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	streamRun(w, r, h.sweepRun(req))
+}
+
+// StreamAllRoots ищет все корни на отрезке, отправляя каждый корень отдельным событием
+func (h *Task4Handler) StreamAllRoots(w http.ResponseWriter, r *http.Request) {
+	var req dto.AllRootsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	streamRun(w, r, h.allRootsRun(req))
+}
+
+// StreamComplexNewton запускает комплексный метод Ньютона с потоковой передачей шагов
+func (h *Task4Handler) StreamComplexNewton(w http.ResponseWriter, r *http.Request) {
+	var req dto.CalculateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	streamRun(w, r, h.complexNewtonRun(req))
+}
+
+// StreamSolveSystem запускает метод решения системы с потоковой передачей шагов
+func (h *Task4Handler) StreamSolveSystem(w http.ResponseWriter, r *http.Request) {
+	method := chi.URLParam(r, "method")
+
+	var req dto.CalculateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	streamRun(w, r, h.systemRun(method, req))
+}
+
+// streamRun запускает run, передавая каждый шаг расчета событием, и завершает
+// поток событием result, interrupted или error. Если ни одного события еще
+// не было, ошибка возвращается обычным JSON-ответом с тем же кодом, что и у
+// непотокового запроса.
+func streamRun(w http.ResponseWriter, r *http.Request, run runFunc) {
+	stream := handutils.NewEventStream(w)
+	ctx := math.WithStepHook(r.Context(), func(step any) {
+		event, payload := dto.StreamEventMapping(step)
+		if event == "" {
+			return
+		}
+		// Ошибка записи означает, что клиент отключился: контекст запроса
+		// отменится, и расчет прервется на следующей итерации
+		_ = stream.Send(event, payload)
+	})
+
+	resp, err := run(ctx)
+
+	if !stream.Started() {
+		if errors.Is(err, math.ErrUnknownMethod) {
+			handutils.RespondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		if respondInterrupted(w, err, resp) {
+			return
+		}
+		if err != nil {
+			handutils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	// Шаги уже отправлены, поэтому частичный результат в interrupted не повторяется
+	var ie *math.InterruptedError
+	switch {
+	case errors.As(err, &ie):
+		_ = stream.Send(dto.EventInterrupted, dto.InterruptedResponse{Error: err.Error(), Status: ie.Status})
+	case err != nil:
+		_ = stream.Send(dto.EventError, errs.HTTPError{Error: err.Error()})
+	default:
+		_ = stream.Send(dto.EventResult, resp)
+	}
+}
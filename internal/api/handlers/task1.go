@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/GeorgeTyupin/numerical_methods/internal/api/handlers/dto"
+	"github.com/GeorgeTyupin/numerical_methods/internal/api/handlers/handutils"
+	"github.com/GeorgeTyupin/numerical_methods/internal/config"
+	errs "github.com/GeorgeTyupin/numerical_methods/internal/errors"
+	"github.com/GeorgeTyupin/numerical_methods/internal/services/engine"
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math"
+	"github.com/go-chi/chi/v5"
+)
+
+const task1Component = "task1_handler"
+
+type Task1Handler struct {
+	logger *slog.Logger
+	engine *engine.Task1Engine
+}
+
+func NewTask1Handler(logger *slog.Logger, limits config.LimitsConfig) *Task1Handler {
+	logger = logger.With(slog.String("component", task1Component))
+	engine, err := engine.NewTask1Engine(logger, limits)
+	if err != nil {
+		logger.Error("failed to create engine", slog.Any("error", err))
+		return nil
+	}
+
+	return &Task1Handler{logger: logger, engine: engine}
+}
+
+// Calculate решает систему линейных уравнений методом из пути запроса
+func (h *Task1Handler) Calculate(w http.ResponseWriter, r *http.Request) {
+	method := chi.URLParam(r, "method")
+
+	var req dto.CalculateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	res, err := h.engine.Solve(r.Context(), method, math.Params(req))
+	if errors.Is(err, math.ErrUnknownMethod) {
+		handutils.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if respondInterrupted(w, err, dto.LinearMapping(method, res)) {
+		return
+	}
+	if err != nil && len(res.Trace) == 0 {
+		handutils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Если итерации не сошлись, график невязки все равно нужен
+	resp := dto.LinearMapping(method, res)
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	handutils.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+// StreamCalculate решает систему с потоковой передачей шагов
+func (h *Task1Handler) StreamCalculate(w http.ResponseWriter, r *http.Request) {
+	method := chi.URLParam(r, "method")
+
+	var req dto.CalculateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	streamRun(w, r, h.solveRun(method, req))
+}
+
+// Analyze возвращает определитель, обратную матрицу, числа обусловленности
+// и результат эксперимента с возмущением правой части
+func (h *Task1Handler) Analyze(w http.ResponseWriter, r *http.Request) {
+	var req dto.CalculateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	res, err := h.engine.Analyze(math.Params(req))
+	if err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	handutils.RespondWithJSON(w, http.StatusOK, dto.MatrixAnalysisMapping(res))
+}
+
+// Eigen находит собственные значения матрицы методом из пути запроса
+func (h *Task1Handler) Eigen(w http.ResponseWriter, r *http.Request) {
+	method := chi.URLParam(r, "method")
+
+	var req dto.CalculateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	res, err := h.engine.Eigen(r.Context(), method, math.Params(req))
+	if errors.Is(err, math.ErrUnknownMethod) {
+		handutils.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if respondInterrupted(w, err, dto.EigenMapping(method, res)) {
+		return
+	}
+	if err != nil && len(res.Steps) == 0 {
+		handutils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Если метод не сошелся, история приближений все равно нужна для графика
+	resp := dto.EigenMapping(method, res)
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	handutils.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+// StreamEigen находит собственные значения с потоковой передачей шагов
+func (h *Task1Handler) StreamEigen(w http.ResponseWriter, r *http.Request) {
+	method := chi.URLParam(r, "method")
+
+	var req dto.CalculateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	streamRun(w, r, h.eigenRun(method, req))
+}
+
+// EigenMethods возвращает список методов поиска собственных значений и их параметры
+func (h *Task1Handler) EigenMethods(w http.ResponseWriter, r *http.Request) {
+	handutils.RespondWithJSON(w, http.StatusOK, dto.EigenMethodMapping(h.engine.EigenMethods()))
+}
+
+// Methods возвращает список методов решения СЛАУ и их параметры
+func (h *Task1Handler) Methods(w http.ResponseWriter, r *http.Request) {
+	handutils.RespondWithJSON(w, http.StatusOK, dto.LinearMethodMapping(h.engine.Methods()))
+}
+
+func (h *Task1Handler) solveRun(method string, req dto.CalculateRequest) runFunc {
+	return func(ctx context.Context) (any, error) {
+		res, err := h.engine.Solve(ctx, method, math.Params(req))
+		resp := dto.LinearMapping(method, res)
+		if err != nil && len(res.Trace) > 0 && !isInterrupted(err) {
+			resp.Error, err = err.Error(), nil
+		}
+		return resp, err
+	}
+}
+
+// analyzeRun считает анализ матрицы целиком: он ограничен порядком матрицы
+// и контекст не проверяет
+func (h *Task1Handler) analyzeRun(req dto.CalculateRequest) runFunc {
+	return func(context.Context) (any, error) {
+		res, err := h.engine.Analyze(math.Params(req))
+		if err != nil {
+			return nil, err
+		}
+		return dto.MatrixAnalysisMapping(res), nil
+	}
+}
+
+func (h *Task1Handler) eigenRun(method string, req dto.CalculateRequest) runFunc {
+	return func(ctx context.Context) (any, error) {
+		res, err := h.engine.Eigen(ctx, method, math.Params(req))
+		resp := dto.EigenMapping(method, res)
+		if err != nil && len(res.Steps) > 0 && !isInterrupted(err) {
+			resp.Error, err = err.Error(), nil
+		}
+		return resp, err
+	}
+}
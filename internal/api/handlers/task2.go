@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/GeorgeTyupin/numerical_methods/internal/api/handlers/dto"
+	"github.com/GeorgeTyupin/numerical_methods/internal/api/handlers/handutils"
+	"github.com/GeorgeTyupin/numerical_methods/internal/config"
+	errs "github.com/GeorgeTyupin/numerical_methods/internal/errors"
+	"github.com/GeorgeTyupin/numerical_methods/internal/services/engine"
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math"
+	"github.com/go-chi/chi/v5"
+)
+
+const task2Component = "task2_handler"
+
+type Task2Handler struct {
+	logger *slog.Logger
+	engine *engine.Task2Engine
+}
+
+func NewTask2Handler(logger *slog.Logger, limits config.LimitsConfig) *Task2Handler {
+	logger = logger.With(slog.String("component", task2Component))
+	engine, err := engine.NewTask2Engine(logger, limits)
+	if err != nil {
+		logger.Error("failed to create engine", slog.Any("error", err))
+		return nil
+	}
+
+	return &Task2Handler{logger: logger, engine: engine}
+}
+
+// Calculate решает задачу Коши методом из пути запроса
+func (h *Task2Handler) Calculate(w http.ResponseWriter, r *http.Request) {
+	method := chi.URLParam(r, "method")
+
+	var req dto.CalculateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	res, err := h.engine.Solve(r.Context(), method, math.Params(req))
+	if errors.Is(err, math.ErrUnknownMethod) {
+		handutils.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if respondInterrupted(w, err, dto.ODEMapping(method, res)) {
+		return
+	}
+	if err != nil && len(res.Steps) == 0 {
+		handutils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Если решение ушло в бесконечность, таблица до этой точки все равно нужна для графика
+	resp := dto.ODEMapping(method, res)
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	handutils.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+// StreamCalculate решает задачу Коши с потоковой передачей узлов таблицы
+func (h *Task2Handler) StreamCalculate(w http.ResponseWriter, r *http.Request) {
+	method := chi.URLParam(r, "method")
+
+	var req dto.CalculateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	streamRun(w, r, h.solveRun(method, req))
+}
+
+// Methods возвращает список методов решения задачи Коши и их параметры
+func (h *Task2Handler) Methods(w http.ResponseWriter, r *http.Request) {
+	handutils.RespondWithJSON(w, http.StatusOK, dto.ODEMethodMapping(h.engine.Methods()))
+}
+
+func (h *Task2Handler) solveRun(method string, req dto.CalculateRequest) runFunc {
+	return func(ctx context.Context) (any, error) {
+		res, err := h.engine.Solve(ctx, method, math.Params(req))
+		resp := dto.ODEMapping(method, res)
+		if err != nil && len(res.Steps) > 0 && !isInterrupted(err) {
+			resp.Error, err = err.Error(), nil
+		}
+		return resp, err
+	}
+}
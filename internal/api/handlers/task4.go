@@ -2,13 +2,18 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"image/png"
 	"log/slog"
 	"net/http"
 
 	"github.com/GeorgeTyupin/numerical_methods/internal/api/handlers/dto"
 	"github.com/GeorgeTyupin/numerical_methods/internal/api/handlers/handutils"
+	"github.com/GeorgeTyupin/numerical_methods/internal/config"
 	errs "github.com/GeorgeTyupin/numerical_methods/internal/errors"
 	"github.com/GeorgeTyupin/numerical_methods/internal/services/engine"
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math"
+	"github.com/go-chi/chi/v5"
 )
 
 const component = "task4_handler"
@@ -18,9 +23,9 @@ type Task4Handler struct {
 	engine *engine.Task4Engine
 }
 
-func NewTask4Handler(logger *slog.Logger) *Task4Handler {
+func NewTask4Handler(logger *slog.Logger, limits config.LimitsConfig) *Task4Handler {
 	logger = logger.With(slog.String("component", component))
-	engine, err := engine.NewTask4Engine(logger)
+	engine, err := engine.NewTask4Engine(logger, limits)
 	if err != nil {
 		logger.Error("failed to create engine", slog.Any("error", err))
 		return nil
@@ -29,90 +34,240 @@ func NewTask4Handler(logger *slog.Logger) *Task4Handler {
 	return &Task4Handler{logger: logger, engine: engine}
 }
 
-func (h *Task4Handler) Newton(w http.ResponseWriter, r *http.Request) {
-	var req dto.NewtonRequest
+// Calculate запускает метод, имя которого передано в пути запроса
+func (h *Task4Handler) Calculate(w http.ResponseWriter, r *http.Request) {
+	method := chi.URLParam(r, "method")
 
+	var req dto.CalculateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
 		return
 	}
 
-	steps, res, iter, err := h.engine.NewtonMethod(
-		req.Formula,
-		req.X0,
-		req.Epsilon,
-	)
+	res, err := h.engine.Solve(r.Context(), method, math.Params(req))
+	if errors.Is(err, math.ErrUnknownMethod) {
+		handutils.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if respondInterrupted(w, err, calculateResponse(method, res)) {
+		return
+	}
 	if err != nil {
 		handutils.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	resp := dto.NewtonResponse{
-		Steps: dto.NewtonStepMapping(steps),
+	handutils.RespondWithJSON(w, http.StatusOK, calculateResponse(method, res))
+}
+
+// calculateResponse собирает ответ метода из реестра
+func calculateResponse(method string, res math.Result) dto.CalculateResponse {
+	return dto.CalculateResponse{
+		Method:      method,
+		Steps:       dto.StepMapping(res),
+		RawSteps:    dto.StepMapping(math.Result{Steps: res.Raw}),
+		Diagnostics: dto.DiagnosticsMapping(res.Diagnostics),
+		Info:        res.Info,
 		BaseResponse: dto.BaseResponse{
-			Root:       res,
-			Iterations: iter,
+			Root:       res.Root,
+			Iterations: res.Iterations,
+			Error:      dto.Finite(res.Diagnostics.ErrorBound),
 		},
+		StopReason: res.StopReason,
 	}
+}
 
-	handutils.RespondWithJSON(w, http.StatusOK, resp)
+// statusClientClosedRequest - нестандартный код, которым принято обозначать
+// запрос, закрытый клиентом до ответа
+const statusClientClosedRequest = 499
+
+// respondInterrupted отвечает на прерванное вычисление, если err - это
+// *math.InterruptedError, и сообщает, был ли отправлен ответ. В partial
+// передается результат, полученный до прерывания.
+func respondInterrupted(w http.ResponseWriter, err error, partial any) bool {
+	var ie *math.InterruptedError
+	if !errors.As(err, &ie) {
+		return false
+	}
+
+	code := statusClientClosedRequest
+	switch ie.Status {
+	case math.InterruptTimeout:
+		code = http.StatusGatewayTimeout
+	case math.InterruptEvalBudget:
+		code = http.StatusUnprocessableEntity
+	}
+
+	handutils.RespondWithJSON(w, code, dto.InterruptedResponse{Error: err.Error(), Status: ie.Status, Partial: partial})
+	return true
 }
 
-func (h *Task4Handler) Dichotomy(w http.ResponseWriter, r *http.Request) {
-	var req dto.DichotomyRequest
+// Sweep решает уравнение для сетки значений параметра формулы
+func (h *Task4Handler) Sweep(w http.ResponseWriter, r *http.Request) {
+	var req dto.SweepRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
 
+	sweepRange := math.SweepRange{Param: req.Param, From: req.From, To: req.To, Points: req.Points}
+	points, err := h.engine.Sweep(r.Context(), req.Method, math.Params(req.Input), sweepRange, req.Continuation)
+	if errors.Is(err, math.ErrUnknownMethod) {
+		handutils.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	resp := dto.SweepResponse{
+		Method: req.Method,
+		Param:  req.Param,
+		Points: dto.SweepPointMapping(points),
+	}
+	if respondInterrupted(w, err, resp) {
+		return
+	}
+	if err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	handutils.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+// AllRoots ищет все корни функции на отрезке и уточняет их выбранным методом
+func (h *Task4Handler) AllRoots(w http.ResponseWriter, r *http.Request) {
+	var req dto.AllRootsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
 		return
 	}
 
-	steps, res, iter, err := h.engine.DichotomyMethod(
-		req.Formula,
-		req.A,
-		req.B,
-		req.Epsilon,
-	)
+	scanRange := math.ScanRange{A: req.A, B: req.B, Samples: req.Samples}
+	scan, err := h.engine.AllRoots(r.Context(), req.Method, math.Params(req.Input), scanRange)
+	if errors.Is(err, math.ErrUnknownMethod) {
+		handutils.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if respondInterrupted(w, err, dto.AllRootsMapping(req.Method, scan)) {
+		return
+	}
 	if err != nil {
 		handutils.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	resp := dto.DichotomyResponse{
-		Steps: dto.DichotomyStepMapping(steps),
-		BaseResponse: dto.BaseResponse{
-			Root:       res,
-			Iterations: iter,
-		},
+	handutils.RespondWithJSON(w, http.StatusOK, dto.AllRootsMapping(req.Method, scan))
+}
+
+// PolynomialRoots находит все корни многочлена, включая комплексные
+func (h *Task4Handler) PolynomialRoots(w http.ResponseWriter, r *http.Request) {
+	var req dto.CalculateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	roots, err := h.engine.PolynomialRoots(r.Context(), math.Params(req))
+	if respondInterrupted(w, err, dto.PolyRootsMapping(roots)) {
+		return
+	}
+	if err != nil && len(roots.Roots) == 0 {
+		handutils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
+	// Если итерации не сошлись, последние приближения и траектория все равно нужны
+	resp := dto.PolyRootsMapping(roots)
+	if err != nil {
+		resp.Error = err.Error()
+	}
 	handutils.RespondWithJSON(w, http.StatusOK, resp)
 }
 
-func (h *Task4Handler) SimpleIter(w http.ResponseWriter, r *http.Request) {
-	var req dto.SimpleIterRequest
+// ComplexNewton запускает метод Ньютона на комплексной плоскости
+func (h *Task4Handler) ComplexNewton(w http.ResponseWriter, r *http.Request) {
+	var req dto.CalculateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
 
+	res, err := h.engine.ComplexNewton(r.Context(), math.Params(req))
+	if respondInterrupted(w, err, dto.ComplexNewtonMapping(res)) {
+		return
+	}
+	if err != nil && len(res.Steps) == 0 {
+		handutils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Если метод не сошелся, траектория все равно нужна для графика
+	resp := dto.ComplexNewtonMapping(res)
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	handutils.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+// Basins возвращает PNG-изображение бассейнов притяжения метода Ньютона
+func (h *Task4Handler) Basins(w http.ResponseWriter, r *http.Request) {
+	var req dto.CalculateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
 		return
 	}
 
-	steps, res, iter, err := h.engine.SimpleIterationMethod(
-		req.Formula,
-		req.X0,
-		req.Epsilon,
-	)
+	img, err := h.engine.Basins(r.Context(), math.Params(req))
+	if respondInterrupted(w, err, nil) {
+		return
+	}
 	if err != nil {
 		handutils.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	resp := dto.SimpleIterResponse{
-		Steps: dto.SimpleIterStepMapping(steps),
-		BaseResponse: dto.BaseResponse{
-			Root:       res,
-			Iterations: iter,
-		},
+	w.Header().Set("Content-Type", "image/png")
+	if err := png.Encode(w, img); err != nil {
+		h.logger.Error("failed to encode png", slog.Any("error", err))
+	}
+}
+
+// SolveSystem запускает метод решения системы нелинейных уравнений
+func (h *Task4Handler) SolveSystem(w http.ResponseWriter, r *http.Request) {
+	method := chi.URLParam(r, "method")
+
+	var req dto.CalculateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handutils.RespondWithError(w, http.StatusBadRequest, errs.ErrInvalidJSON.Error())
+		return
+	}
+
+	res, err := h.engine.SolveSystem(r.Context(), method, math.Params(req))
+	if errors.Is(err, math.ErrUnknownMethod) {
+		handutils.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if respondInterrupted(w, err, dto.SystemMapping(method, res)) {
+		return
+	}
+	if err != nil && len(res.Steps) == 0 {
+		handutils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
+	// Если метод не сошелся, траектория все равно нужна для графика
+	resp := dto.SystemMapping(method, res)
+	if err != nil {
+		resp.Error = err.Error()
+	}
 	handutils.RespondWithJSON(w, http.StatusOK, resp)
 }
+
+// SystemMethods возвращает список методов решения систем и их параметры
+func (h *Task4Handler) SystemMethods(w http.ResponseWriter, r *http.Request) {
+	handutils.RespondWithJSON(w, http.StatusOK, dto.SystemMethodMapping(h.engine.SystemMethods()))
+}
+
+// Methods возвращает список доступных методов и их параметры
+func (h *Task4Handler) Methods(w http.ResponseWriter, r *http.Request) {
+	handutils.RespondWithJSON(w, http.StatusOK, dto.MethodMapping(h.engine.Methods()))
+}
@@ -5,11 +5,13 @@ import (
 	"net/http"
 
 	"github.com/GeorgeTyupin/numerical_methods/internal/api/handlers"
+	"github.com/GeorgeTyupin/numerical_methods/internal/config"
+	"github.com/GeorgeTyupin/numerical_methods/internal/services/jobs"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
-func RegisterRoutes(logger *slog.Logger) *chi.Mux {
+func RegisterRoutes(logger *slog.Logger, cfg *config.Config, manager *jobs.Manager) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
@@ -21,15 +23,67 @@ func RegisterRoutes(logger *slog.Logger) *chi.Mux {
 
 	r.Get("/", handlers.Index)
 
+	task1 := handlers.NewTask1Handler(logger, cfg.Limits)
+	task2 := handlers.NewTask2Handler(logger, cfg.Limits)
+	task4 := handlers.NewTask4Handler(logger, cfg.Limits)
+
 	r.Route("/api/v1/calculate", func(r chi.Router) {
-		task4 := handlers.NewTask4Handler(logger)
+		r.Route("/task1", func(r chi.Router) {
+			r.Get("/methods", task1.Methods)
+			r.Post("/analyze", task1.Analyze)
+			r.Get("/eigen/methods", task1.EigenMethods)
+			r.Post("/eigen/{method}", task1.Eigen)
+			r.Post("/{method}", task1.Calculate)
+		})
+
+		r.Route("/task2", func(r chi.Router) {
+			r.Get("/methods", task2.Methods)
+			r.Post("/{method}", task2.Calculate)
+		})
+
+		r.Route("/task4", func(r chi.Router) {
+			r.Get("/methods", task4.Methods)
+			r.Post("/sweep", task4.Sweep)
+			r.Post("/all_roots", task4.AllRoots)
+			r.Post("/poly_roots", task4.PolynomialRoots)
+			r.Post("/complex_newton", task4.ComplexNewton)
+			r.Post("/basins", task4.Basins)
+			r.Get("/system/methods", task4.SystemMethods)
+			r.Post("/system/{method}", task4.SolveSystem)
+			r.Post("/{method}", task4.Calculate)
+		})
+	})
+
+	// Те же вычисления с передачей шагов по мере расчета (Server-Sent Events)
+	r.Route("/api/v1/stream", func(r chi.Router) {
+		r.Post("/task1/eigen/{method}", task1.StreamEigen)
+		r.Post("/task1/{method}", task1.StreamCalculate)
+		r.Post("/task2/{method}", task2.StreamCalculate)
 
 		r.Route("/task4", func(r chi.Router) {
-			r.Post("/dichotomy", task4.Dichotomy)
-			r.Post("/newton", task4.Newton)
-			r.Post("/simple_iter", task4.SimpleIter)
+			r.Post("/sweep", task4.StreamSweep)
+			r.Post("/all_roots", task4.StreamAllRoots)
+			r.Post("/complex_newton", task4.StreamComplexNewton)
+			r.Post("/system/{method}", task4.StreamSolveSystem)
+			r.Post("/{method}", task4.StreamCalculate)
 		})
 	})
 
+	// Фоновые задания: любое вычисление без удержания соединения. Задания
+	// выполняются теми же обработчиками, но со своими пределами времени и
+	// числа вычислений функции (jobs.timeout, jobs.max_evaluations).
+	r.Route("/api/v1/jobs", func(r chi.Router) {
+		jobLimits := cfg.JobLimits()
+		jobs := handlers.NewJobsHandler(logger, manager,
+			handlers.NewTask1Handler(logger, jobLimits),
+			handlers.NewTask2Handler(logger, jobLimits),
+			handlers.NewTask4Handler(logger, jobLimits),
+		)
+
+		r.Post("/", jobs.Submit)
+		r.Get("/{id}", jobs.Get)
+		r.Delete("/{id}", jobs.Cancel)
+	})
+
 	return r
 }
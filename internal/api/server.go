@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/GeorgeTyupin/numerical_methods/internal/config"
+	"github.com/GeorgeTyupin/numerical_methods/internal/services/jobs"
 )
 
 const component = "api"
@@ -18,12 +19,15 @@ type HttpServer struct {
 func NewHttpServer(logger *slog.Logger, cfg *config.Config) *HttpServer {
 	logger = logger.With(slog.String("component", component))
 
-	mux := RegisterRoutes(logger)
+	manager := jobs.NewManager(logger, cfg.Jobs)
+	mux := RegisterRoutes(logger, cfg, manager)
 
 	server := &http.Server{
 		Addr:    cfg.Server.Port,
 		Handler: mux,
 	}
+	// Выполняющиеся задания отменяются вместе с остановкой сервера
+	server.RegisterOnShutdown(manager.Close)
 
 	return &HttpServer{
 		logger: logger,
@@ -16,6 +16,8 @@ const (
 
 type Config struct {
 	Server ServerConfig `yaml:"http_server"`
+	Limits LimitsConfig `yaml:"limits"`
+	Jobs   JobsConfig   `yaml:"jobs"`
 }
 
 type ServerConfig struct {
@@ -27,6 +29,33 @@ type ServerTimeoutsConfig struct {
 	Shutdown time.Duration `yaml:"shutdown"`
 }
 
+// LimitsConfig - ограничения на вычисления, которые может запросить клиент
+type LimitsConfig struct {
+	MaxIter        int           `yaml:"max_iter" env-default:"100000"`          // Наибольший допустимый max_iter в запросе
+	Timeout        time.Duration `yaml:"timeout" env-default:"10s"`              // Время на вычисление одного запроса
+	MaxEvaluations int           `yaml:"max_evaluations" env-default:"50000000"` // Бюджет вычислений функции на запрос
+}
+
+// JobsConfig - пул фоновых заданий (/api/v1/jobs)
+type JobsConfig struct {
+	Workers        int           `yaml:"workers" env-default:"4"`                  // Сколько заданий выполняется одновременно
+	Queue          int           `yaml:"queue" env-default:"64"`                   // Сколько заданий может ждать в очереди
+	TTL            time.Duration `yaml:"ttl" env-default:"10m"`                    // Сколько хранится результат завершенного задания
+	Timeout        time.Duration `yaml:"timeout" env-default:"5m"`                 // Время на вычисление одного задания
+	MaxEvaluations int           `yaml:"max_evaluations" env-default:"1000000000"` // Бюджет вычислений функции на задание
+}
+
+// JobLimits возвращает ограничения для фоновых заданий: предел max_iter
+// общий с запросами, а время и бюджет вычислений - из JobsConfig, потому
+// что задания предназначены для расчетов дольше обычного запроса
+func (c *Config) JobLimits() LimitsConfig {
+	return LimitsConfig{
+		MaxIter:        c.Limits.MaxIter,
+		Timeout:        c.Jobs.Timeout,
+		MaxEvaluations: c.Jobs.MaxEvaluations,
+	}
+}
+
 func MustLoad(logger *slog.Logger) *Config {
 	const op = "MustLoad"
 	logger = logger.With(slog.String("component", component), slog.String("op", op))
@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	nm "github.com/GeorgeTyupin/numerical_methods/pkg/math"
+)
+
+// basinsPalette - цвета бассейнов первых корней, дальше цвета строятся по золотому углу
+var basinsPalette = []color.RGBA{
+	{R: 0x63, G: 0x66, B: 0xf1, A: 0xff},
+	{R: 0xf5, G: 0x9e, B: 0x0b, A: 0xff},
+	{R: 0x10, G: 0xb9, B: 0x81, A: 0xff},
+	{R: 0xef, G: 0x44, B: 0x44, A: 0xff},
+	{R: 0x06, G: 0xb6, B: 0xd4, A: 0xff},
+	{R: 0xec, G: 0x48, B: 0x99, A: 0xff},
+	{R: 0x84, G: 0xcc, B: 0x16, A: 0xff},
+	{R: 0xa8, G: 0x55, B: 0xf7, A: 0xff},
+}
+
+// renderBasins раскрашивает бассейны: цвет точки определяется корнем, к которому
+// сошелся метод, а яркость убывает с числом итераций. Точки, из которых метод
+// не сошелся, остаются черными.
+func renderBasins(b nm.Basins) *image.RGBA {
+	r := b.Region
+	img := image.NewRGBA(image.Rect(0, 0, r.Width, r.Height))
+
+	colors := make([]color.RGBA, len(b.Roots))
+	for k := range colors {
+		if k < len(basinsPalette) {
+			colors[k] = basinsPalette[k]
+		} else {
+			colors[k] = hueColor(math.Mod(float64(k)*137.508, 360))
+		}
+	}
+
+	maxShade := math.Log1p(float64(r.MaxIter))
+	for i, k := range b.Root {
+		if k < 0 {
+			img.SetRGBA(i%r.Width, i/r.Width, color.RGBA{A: 0xff})
+			continue
+		}
+
+		shade := 1 - 0.8*math.Log1p(float64(b.Iterations[i]-1))/maxShade
+		c := colors[k]
+		img.SetRGBA(i%r.Width, i/r.Width, color.RGBA{
+			R: uint8(float64(c.R) * shade),
+			G: uint8(float64(c.G) * shade),
+			B: uint8(float64(c.B) * shade),
+			A: 0xff,
+		})
+	}
+
+	return img
+}
+
+// hueColor возвращает насыщенный цвет с заданным тоном (в градусах)
+func hueColor(hue float64) color.RGBA {
+	channel := func(n float64) uint8 {
+		k := math.Mod(n+hue/60, 6)
+		v := 1 - math.Max(0, math.Min(math.Min(k, 4-k), 1))
+		return uint8(255 * (0.2 + 0.7*v))
+	}
+	return color.RGBA{R: channel(5), G: channel(3), B: channel(1), A: 0xff}
+}
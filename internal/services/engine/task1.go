@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/GeorgeTyupin/numerical_methods/internal/config"
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math"
+)
+
+// Task1Engine решает системы линейных алгебраических уравнений (задание 1)
+type Task1Engine struct {
+	logger *slog.Logger
+	limits config.LimitsConfig
+}
+
+func NewTask1Engine(logger *slog.Logger, limits config.LimitsConfig) (*Task1Engine, error) {
+	logger = logger.With(slog.String("component", component))
+
+	return &Task1Engine{
+		logger: logger,
+		limits: limits,
+	}, nil
+}
+
+// Solve создает метод решения СЛАУ по имени и запускает вычисление
+func (e *Task1Engine) Solve(ctx context.Context, method string, params math.Params) (math.LinearResult, error) {
+	const op = "solve_linear"
+	logger := e.logger.With(slog.String("op", op), slog.String("method", method))
+
+	params, err := limitIterations(params, e.limits)
+	if err != nil {
+		logger.Error("iteration limit exceeded", slog.Any("error", err))
+		return math.LinearResult{}, err
+	}
+
+	solver, err := math.NewLinearSolver(method, params)
+	if err != nil {
+		logger.Error("failed to create linear solver", slog.Any("error", err))
+		return math.LinearResult{}, err
+	}
+
+	ctx, cancel := withBudget(ctx, e.limits)
+	defer cancel()
+
+	res, err := solver.Calculate(ctx)
+	logInterrupted(logger, err)
+	return res, err
+}
+
+// Analyze вычисляет обусловленность матрицы и чувствительность решения к возмущениям
+func (e *Task1Engine) Analyze(params math.Params) (math.MatrixAnalysis, error) {
+	const op = "analyze_matrix"
+	logger := e.logger.With(slog.String("op", op))
+
+	res, err := math.AnalyzeMatrix(params)
+	if err != nil {
+		logger.Error("failed to analyze matrix", slog.Any("error", err))
+		return res, err
+	}
+
+	return res, nil
+}
+
+// Eigen создает метод поиска собственных значений по имени и запускает вычисление
+func (e *Task1Engine) Eigen(ctx context.Context, method string, params math.Params) (math.EigenResult, error) {
+	const op = "eigen"
+	logger := e.logger.With(slog.String("op", op), slog.String("method", method))
+
+	params, err := limitIterations(params, e.limits)
+	if err != nil {
+		logger.Error("iteration limit exceeded", slog.Any("error", err))
+		return math.EigenResult{}, err
+	}
+
+	solver, err := math.NewEigenSolver(method, params)
+	if err != nil {
+		logger.Error("failed to create eigen solver", slog.Any("error", err))
+		return math.EigenResult{}, err
+	}
+
+	ctx, cancel := withBudget(ctx, e.limits)
+	defer cancel()
+
+	res, err := solver.Calculate(ctx)
+	logInterrupted(logger, err)
+	return res, err
+}
+
+// EigenMethods возвращает список методов поиска собственных значений со схемами параметров
+func (e *Task1Engine) EigenMethods() []math.EigenMethod {
+	return math.EigenMethods()
+}
+
+// Methods возвращает список методов решения СЛАУ со схемами параметров
+func (e *Task1Engine) Methods() []math.LinearMethod {
+	return math.LinearMethods()
+}
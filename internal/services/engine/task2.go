@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/GeorgeTyupin/numerical_methods/internal/config"
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math"
+)
+
+// Task2Engine решает задачу Коши для обыкновенных дифференциальных уравнений (задание 2)
+type Task2Engine struct {
+	logger *slog.Logger
+	limits config.LimitsConfig
+}
+
+func NewTask2Engine(logger *slog.Logger, limits config.LimitsConfig) (*Task2Engine, error) {
+	logger = logger.With(slog.String("component", component))
+
+	return &Task2Engine{
+		logger: logger,
+		limits: limits,
+	}, nil
+}
+
+// Solve создает метод решения задачи Коши по имени и запускает вычисление
+func (e *Task2Engine) Solve(ctx context.Context, method string, params math.Params) (math.ODEResult, error) {
+	const op = "solve_ode"
+	logger := e.logger.With(slog.String("op", op), slog.String("method", method))
+
+	solver, err := math.NewODESolver(method, params)
+	if err != nil {
+		logger.Error("failed to create ode solver", slog.Any("error", err))
+		return math.ODEResult{}, err
+	}
+
+	ctx, cancel := withBudget(ctx, e.limits)
+	defer cancel()
+
+	res, err := solver.Calculate(ctx)
+	logInterrupted(logger, err)
+	return res, err
+}
+
+// Methods возвращает список методов решения задачи Коши со схемами параметров
+func (e *Task2Engine) Methods() []math.ODEMethod {
+	return math.ODEMethods()
+}
@@ -1,65 +1,234 @@
 package engine
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
 	"log/slog"
+	"maps"
 
+	"github.com/GeorgeTyupin/numerical_methods/internal/config"
 	"github.com/GeorgeTyupin/numerical_methods/pkg/math"
 )
 
 const component = "engine"
 
 type Task4Engine struct {
-	logger  *slog.Logger
-	funcStr string
-	x0      float64
-	epsilon float64
-	maxIter int
+	logger *slog.Logger
+	limits config.LimitsConfig
 }
 
-func NewTask4Engine(logger *slog.Logger) (*Task4Engine, error) {
+func NewTask4Engine(logger *slog.Logger, limits config.LimitsConfig) (*Task4Engine, error) {
 	logger = logger.With(slog.String("component", component))
 
 	return &Task4Engine{
 		logger: logger,
+		limits: limits,
 	}, nil
 }
 
-func (e *Task4Engine) NewtonMethod(funcStr string, x0, epsilon float64) ([]math.NewtonStep, float64, int, error) {
-	const op = "newton"
-	logger := e.logger.With(slog.String("op", op))
+// budget ограничивает вычисление по запросу временем и числом вычислений функции из конфигурации
+func (e *Task4Engine) budget(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withBudget(ctx, e.limits)
+}
+
+func withBudget(ctx context.Context, limits config.LimitsConfig) (context.Context, context.CancelFunc) {
+	if limits.MaxEvaluations > 0 {
+		ctx = math.WithEvalBudget(ctx, limits.MaxEvaluations)
+	}
+	if limits.Timeout > 0 {
+		return context.WithTimeout(ctx, limits.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// logInterrupted пишет в журнал прерванные вычисления; остальные ошибки
+// методов (расходимость, вырожденная производная) - обычный результат расчета
+func logInterrupted(logger *slog.Logger, err error) {
+	var ie *math.InterruptedError
+	if errors.As(err, &ie) {
+		logger.Warn("calculation interrupted", slog.String("status", ie.Status), slog.Int("evaluations", ie.Evaluations))
+	}
+}
+
+// limitIterations проверяет max_iter запроса по серверному ограничению. Если
+// предел не задан, а ограничение ниже предела по умолчанию, подставляется ограничение.
+// Общая для всех движков, методы которых читают max_iter.
+func limitIterations(params math.Params, limits config.LimitsConfig) (math.Params, error) {
+	if !params.Has("max_iter") {
+		if limits.MaxIter > 0 && limits.MaxIter < math.DefaultMaxIter {
+			limited := make(math.Params, len(params)+1)
+			maps.Copy(limited, params)
+			limited["max_iter"] = float64(limits.MaxIter)
+			return limited, nil
+		}
+		return params, nil
+	}
+	if limits.MaxIter > 0 && params.Float("max_iter") > float64(limits.MaxIter) {
+		return nil, fmt.Errorf("max_iter не может превышать %d", limits.MaxIter)
+	}
+	return params, nil
+}
+
+// Solve создает метод из реестра по имени и запускает вычисление
+func (e *Task4Engine) Solve(ctx context.Context, method string, params math.Params) (math.Result, error) {
+	const op = "solve"
+	logger := e.logger.With(slog.String("op", op), slog.String("method", method))
+
+	params, err := limitIterations(params, e.limits)
+	if err != nil {
+		logger.Error("iteration limit exceeded", slog.Any("error", err))
+		return math.Result{}, err
+	}
 
-	calculator, err := math.NewNewtonMethodCalculator(funcStr, x0, epsilon)
+	solver, err := math.NewSolver(method, params)
 	if err != nil {
 		logger.Error("failed to create calculator", slog.Any("error", err))
-		return nil, 0, 0, err
+		return math.Result{}, err
+	}
+
+	ctx, cancel := e.budget(ctx)
+	defer cancel()
+
+	res, err := solver.Calculate(ctx)
+	logInterrupted(logger, err)
+	return res, err
+}
+
+// Sweep решает уравнение для сетки значений параметра формулы
+// При прерывании возвращаются уже посчитанные точки.
+func (e *Task4Engine) Sweep(ctx context.Context, method string, params math.Params, r math.SweepRange, continuation bool) ([]math.SweepPoint, error) {
+	const op = "sweep"
+	logger := e.logger.With(slog.String("op", op), slog.String("method", method))
+
+	params, err := limitIterations(params, e.limits)
+	if err != nil {
+		logger.Error("iteration limit exceeded", slog.Any("error", err))
+		return nil, err
+	}
+
+	ctx, cancel := e.budget(ctx)
+	defer cancel()
+
+	points, err := math.Sweep(ctx, method, params, r, continuation)
+	if err != nil {
+		logger.Error("failed to run sweep", slog.Any("error", err))
+		return points, err
+	}
+
+	return points, nil
+}
+
+// AllRoots ищет все корни функции на отрезке и уточняет каждый выбранным методом.
+// При прерывании возвращаются уже найденные корни.
+func (e *Task4Engine) AllRoots(ctx context.Context, method string, params math.Params, r math.ScanRange) (math.RootScan, error) {
+	const op = "all_roots"
+	logger := e.logger.With(slog.String("op", op), slog.String("method", method))
+
+	params, err := limitIterations(params, e.limits)
+	if err != nil {
+		logger.Error("iteration limit exceeded", slog.Any("error", err))
+		return math.RootScan{}, err
 	}
 
-	return calculator.Calculate()
+	ctx, cancel := e.budget(ctx)
+	defer cancel()
+
+	scan, err := math.AllRoots(ctx, method, params, r)
+	if err != nil {
+		logger.Error("failed to scan interval", slog.Any("error", err))
+		return scan, err
+	}
 
+	return scan, nil
 }
 
-func (e *Task4Engine) DichotomyMethod(funcStr string, a, b, epsilon float64) ([]math.DichotomyStep, float64, int, error) {
-	const op = "dichotomy"
+// PolynomialRoots находит все корни многочлена, включая комплексные.
+// Если итерации не сошлись или прерваны, возвращаются последние приближения.
+func (e *Task4Engine) PolynomialRoots(ctx context.Context, params math.Params) (math.PolyRoots, error) {
+	const op = "poly_roots"
 	logger := e.logger.With(slog.String("op", op))
 
-	calculator, err := math.NewDichotomyMethodCalculator(funcStr, a, b, epsilon)
+	ctx, cancel := e.budget(ctx)
+	defer cancel()
+
+	roots, err := math.PolynomialRoots(ctx, params)
 	if err != nil {
-		logger.Error("failed to create calculator", slog.Any("error", err))
-		return nil, 0, 0, err
+		logger.Error("failed to find polynomial roots", slog.Any("error", err))
+	}
+	return roots, err
+}
+
+// ComplexNewton запускает метод Ньютона на комплексной плоскости
+func (e *Task4Engine) ComplexNewton(ctx context.Context, params math.Params) (math.ComplexResult, error) {
+	const op = "complex_newton"
+	logger := e.logger.With(slog.String("op", op))
+
+	params, err := limitIterations(params, e.limits)
+	if err != nil {
+		logger.Error("iteration limit exceeded", slog.Any("error", err))
+		return math.ComplexResult{}, err
 	}
 
-	return calculator.Calculate()
+	ctx, cancel := e.budget(ctx)
+	defer cancel()
+
+	res, err := math.ComplexNewton(ctx, params)
+	if err != nil {
+		logger.Error("failed to run complex newton", slog.Any("error", err))
+	}
+	return res, err
 }
 
-func (e *Task4Engine) SimpleIterationMethod(funcStr string, x0, epsilon float64) ([]math.SimpleIterStep, float64, int, error) {
-	const op = "simple_iteration"
+// Basins строит изображение бассейнов притяжения метода Ньютона
+func (e *Task4Engine) Basins(ctx context.Context, params math.Params) (image.Image, error) {
+	const op = "basins"
 	logger := e.logger.With(slog.String("op", op))
 
-	calculator, err := math.NewSimpleIterationMethodCalculator(funcStr, x0, epsilon)
+	ctx, cancel := e.budget(ctx)
+	defer cancel()
+
+	basins, err := math.NewtonBasins(ctx, params)
 	if err != nil {
-		logger.Error("failed to create calculator", slog.Any("error", err))
-		return nil, 0, 0, err
+		logger.Error("failed to compute basins", slog.Any("error", err))
+		return nil, err
+	}
+
+	return renderBasins(basins), nil
+}
+
+// SolveSystem создает метод решения систем по имени и запускает вычисление
+func (e *Task4Engine) SolveSystem(ctx context.Context, method string, params math.Params) (math.SystemResult, error) {
+	const op = "solve_system"
+	logger := e.logger.With(slog.String("op", op), slog.String("method", method))
+
+	params, err := limitIterations(params, e.limits)
+	if err != nil {
+		logger.Error("iteration limit exceeded", slog.Any("error", err))
+		return math.SystemResult{}, err
 	}
 
-	return calculator.Calculate()
+	solver, err := math.NewSystemSolver(method, params)
+	if err != nil {
+		logger.Error("failed to create system solver", slog.Any("error", err))
+		return math.SystemResult{}, err
+	}
+
+	ctx, cancel := e.budget(ctx)
+	defer cancel()
+
+	res, err := solver.Calculate(ctx)
+	logInterrupted(logger, err)
+	return res, err
+}
+
+// SystemMethods возвращает список методов решения систем со схемами параметров
+func (e *Task4Engine) SystemMethods() []math.SystemMethod {
+	return math.SystemMethods()
+}
+
+// Methods возвращает список доступных методов со схемами параметров
+func (e *Task4Engine) Methods() []math.Method {
+	return math.Methods()
 }
@@ -0,0 +1,305 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/GeorgeTyupin/numerical_methods/internal/config"
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math"
+)
+
+const component = "jobs"
+
+var (
+	ErrQueueFull = errors.New("очередь заданий заполнена, повторите позже")
+	ErrNotFound  = errors.New("задание не найдено или срок хранения его результата истек")
+	ErrClosed    = errors.New("сервер завершает работу и не принимает задания")
+
+	errCancelled = errors.New("задание отменено")
+)
+
+// Status - состояние задания
+type Status string
+
+const (
+	StatusQueued    Status = "queued"    // Ждет свободного исполнителя
+	StatusRunning   Status = "running"   // Выполняется
+	StatusDone      Status = "done"      // Завершено, результат готов
+	StatusFailed    Status = "failed"    // Завершено с ошибкой (результат может быть частичным)
+	StatusCancelled Status = "cancelled" // Отменено клиентом или при остановке сервера
+)
+
+// Task - вычисление, выполняемое заданием. Возвращает тело ответа (при
+// ошибке - частичный результат, если он есть) и ошибку.
+type Task func(ctx context.Context) (any, error)
+
+// Snapshot - состояние задания на момент запроса
+type Snapshot struct {
+	ID       string
+	Kind     string // Вид вычисления, переданный при постановке в очередь
+	Status   Status
+	Steps    int // Сколько шагов (точек, корней) уже вычислено
+	Total    int // Ожидаемое число шагов, если оно известно заранее, иначе 0
+	Result   any
+	Err      string
+	Created  time.Time
+	Started  time.Time // Нулевое время, пока задание в очереди
+	Finished time.Time // Нулевое время, пока задание не завершено
+	Expires  time.Time // Когда результат будет удален; нулевое время для незавершенных
+}
+
+type job struct {
+	Snapshot
+	task      Task
+	cancel    context.CancelFunc // Отменяет выполнение; nil, пока задание в очереди
+	cancelled bool               // Отмену запросил клиент
+	steps     atomic.Int64
+}
+
+// snapshot копирует состояние задания. Вызывается под Manager.mu.
+func (j *job) snapshot() Snapshot {
+	s := j.Snapshot
+	s.Steps = int(j.steps.Load())
+	return s
+}
+
+// Manager выполняет задания на ограниченном пуле исполнителей и хранит
+// результаты завершенных заданий в течение TTL
+type Manager struct {
+	logger *slog.Logger
+	ttl    time.Duration
+
+	mu     sync.Mutex
+	jobs   map[string]*job
+	closed bool
+
+	queue  chan *job
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewManager запускает исполнителей и очистку устаревших результатов
+func NewManager(logger *slog.Logger, cfg config.JobsConfig) *Manager {
+	logger = logger.With(slog.String("component", component))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m := &Manager{
+		logger: logger,
+		ttl:    cfg.TTL,
+		jobs:   make(map[string]*job),
+		queue:  make(chan *job, max(cfg.Queue, 0)),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	workers := max(cfg.Workers, 1)
+	m.wg.Add(workers + 1)
+	for range workers {
+		go m.worker()
+	}
+	go m.janitor()
+
+	logger.Info("пул заданий запущен", slog.Int("workers", workers), slog.Int("queue", cap(m.queue)), slog.Duration("ttl", m.ttl))
+	return m
+}
+
+// Submit ставит вычисление в очередь. total - ожидаемое число шагов для
+// оценки прогресса или 0, если оно неизвестно.
+func (m *Manager) Submit(kind string, task Task, total int) (Snapshot, error) {
+	id, err := newID()
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	j := &job{
+		Snapshot: Snapshot{ID: id, Kind: kind, Status: StatusQueued, Total: total, Created: time.Now()},
+		task:     task,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return Snapshot{}, ErrClosed
+	}
+	select {
+	case m.queue <- j:
+	default:
+		return Snapshot{}, ErrQueueFull
+	}
+	m.jobs[id] = j
+
+	return j.snapshot(), nil
+}
+
+// Get возвращает состояние задания
+func (m *Manager) Get(id string) (Snapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.lookup(id)
+	if !ok {
+		return Snapshot{}, ErrNotFound
+	}
+	return j.snapshot(), nil
+}
+
+// Cancel отменяет задание. Задание из очереди отменяется сразу, у
+// выполняющегося прерывается расчет, а завершенное остается как есть.
+func (m *Manager) Cancel(id string) (Snapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.lookup(id)
+	if !ok {
+		return Snapshot{}, ErrNotFound
+	}
+
+	switch j.Status {
+	case StatusQueued:
+		j.cancelled = true
+		m.finish(j, StatusCancelled, nil, errCancelled)
+	case StatusRunning:
+		j.cancelled = true
+		j.cancel()
+	}
+	return j.snapshot(), nil
+}
+
+// Close отменяет все задания и дожидается остановки исполнителей
+func (m *Manager) Close() {
+	m.mu.Lock()
+	m.closed = true
+	m.mu.Unlock()
+
+	m.cancel()
+	m.wg.Wait()
+}
+
+// lookup находит задание, не отдавая результаты с истекшим сроком хранения.
+// Вызывается под m.mu.
+func (m *Manager) lookup(id string) (*job, bool) {
+	j, ok := m.jobs[id]
+	if !ok || (!j.Expires.IsZero() && time.Now().After(j.Expires)) {
+		return nil, false
+	}
+	return j, true
+}
+
+func (m *Manager) worker() {
+	defer m.wg.Done()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case j := <-m.queue:
+			m.run(j)
+		}
+	}
+}
+
+// run выполняет задание, считая шаги расчета для прогресса
+func (m *Manager) run(j *job) {
+	m.mu.Lock()
+	if j.Status != StatusQueued {
+		// Отменено, пока ждало в очереди
+		m.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	defer cancel()
+	j.Status, j.Started, j.cancel = StatusRunning, time.Now(), cancel
+	m.mu.Unlock()
+
+	ctx = math.WithStepHook(ctx, func(any) { j.steps.Add(1) })
+	result, err := m.execute(ctx, j)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := StatusDone
+	switch {
+	case j.cancelled || m.ctx.Err() != nil:
+		status = StatusCancelled
+	case err != nil:
+		status = StatusFailed
+	}
+	m.finish(j, status, result, err)
+
+	m.logger.Info("задание завершено",
+		slog.String("id", j.ID),
+		slog.String("kind", j.Kind),
+		slog.String("status", string(status)),
+		slog.Duration("duration", j.Finished.Sub(j.Started)),
+	)
+}
+
+// execute вызывает вычисление задания. Паника внутри вычисления не должна
+// останавливать воркер и оставлять задание в статусе running, поэтому она
+// превращается в ошибку задания.
+func (m *Manager) execute(ctx context.Context, j *job) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.logger.Error("паника при выполнении задания",
+				slog.String("id", j.ID),
+				slog.String("kind", j.Kind),
+				slog.Any("panic", r),
+				slog.String("stack", string(debug.Stack())),
+			)
+			result, err = nil, fmt.Errorf("внутренняя ошибка вычисления: %v", r)
+		}
+	}()
+	return j.task(ctx)
+}
+
+// finish фиксирует итог задания и срок хранения результата. Вызывается под m.mu.
+func (m *Manager) finish(j *job, status Status, result any, err error) {
+	j.Status, j.Result = status, result
+	if err != nil {
+		j.Err = err.Error()
+	}
+	j.Finished = time.Now()
+	j.Expires = j.Finished.Add(m.ttl)
+}
+
+// janitor периодически удаляет результаты с истекшим сроком хранения
+func (m *Manager) janitor() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(max(m.ttl/2, time.Second))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.mu.Lock()
+			for id, j := range m.jobs {
+				if !j.Expires.IsZero() && now.After(j.Expires) {
+					delete(m.jobs, id)
+				}
+			}
+			m.mu.Unlock()
+		}
+	}
+}
+
+// newID возвращает случайный идентификатор задания
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
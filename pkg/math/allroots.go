@@ -0,0 +1,334 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+)
+
+// Виды изолированных корней
+const (
+	RootSignChange = "sign_change" // Функция меняет знак на отрезке локализации
+	RootTangent    = "tangent"     // Касание оси без смены знака (корень четной кратности)
+	RootExact      = "exact"       // Точное попадание в корень при сканировании
+)
+
+// Виды особых точек, в которых функция меняет знак без корня
+const (
+	SingularityPole = "pole" // Функция неограниченно растет (например, 1/x или tan x)
+	SingularityJump = "jump" // Конечный разрыв (например, sign x)
+)
+
+// StepGolden - шаг метода золотого сечения при уточнении корня четной кратности
+const StepGolden = "golden"
+
+// Золотое сечение: доля отрезка, отбрасываемая на каждом шаге
+var goldenRatio = (3 - math.Sqrt(5)) / 2
+
+// ScanRange - отрезок сканирования и число точек начальной равномерной сетки
+type ScanRange struct {
+	A       float64
+	B       float64
+	Samples int
+}
+
+// IsolatedRoot - корень, найденный при сканировании, вместе с отрезком локализации
+type IsolatedRoot struct {
+	A      float64 // Левая граница отрезка локализации
+	B      float64 // Правая граница отрезка локализации
+	Kind   string  // RootSignChange, RootTangent или RootExact
+	Method string  // Метод, которым уточнялся корень
+	Result Result  // Траектория уточнения
+	Err    error   // Ошибка уточнения (остальные корни при этом ищутся)
+}
+
+// Singularity - точка смены знака функции, не являющаяся корнем
+type Singularity struct {
+	X    float64
+	Kind string // SingularityPole или SingularityJump
+}
+
+// RootScan - результат поиска всех корней на отрезке
+type RootScan struct {
+	Roots         []IsolatedRoot
+	Singularities []Singularity
+	Evaluations   int // Количество вычислений функции при сканировании
+}
+
+// scanSpecs - параметры, общие для сканирования и всех методов уточнения
+var scanSpecs = []ParamSpec{formulaParam, paramsParam, epsilonParam}
+
+// AllRoots ищет все корни функции на отрезке [A, B].
+//
+// Функция вычисляется на равномерной сетке, которая адаптивно сгущается там,
+// где график заметно отклоняется от прямой. Затем соседние точки сетки
+// просматриваются в поиске трех признаков:
+//   - смена знака - отрезок сужается делением пополам, и по поведению |f|
+//     определяется, корень это (|f| убывает), полюс (|f| растет) или разрыв;
+//   - локальный минимум |f| без смены знака - минимум уточняется золотым
+//     сечением, и если |f| в нем не превышает epsilon, это корень четной
+//     кратности (если же знак в минимуме сменился, найдены два близких корня);
+//   - точный ноль в узле сетки.
+//
+// Каждый отрезок со сменой знака уточняется методом method из реестра:
+// интервальным методам передаются концы отрезка, методам с начальным
+// приближением - его середина (методу секущих - оба конца).
+func AllRoots(ctx context.Context, method string, p Params, r ScanRange) (RootScan, error) {
+	var scan RootScan
+
+	m, ok := Lookup(method)
+	if !ok {
+		return scan, fmt.Errorf("%w: %q", ErrUnknownMethod, method)
+	}
+	// Методы, формула которых задает φ(x), а не f(x), для уточнения корней f не годятся
+	bracketed := m.bracketed()
+	if m.FixedPoint || (!bracketed && !m.hasParam("x0")) {
+		return scan, fmt.Errorf("метод %q не подходит для уточнения корней на отрезке", method)
+	}
+
+	if r.Samples == 0 {
+		r.Samples = defaultScanSamples
+	}
+	if r.Samples < 2 || r.Samples > maxScanSamples {
+		return scan, fmt.Errorf("количество точек сетки должно быть от 2 до %d", maxScanSamples)
+	}
+	if !(r.A < r.B) {
+		return scan, fmt.Errorf("левая граница отрезка должна быть меньше правой")
+	}
+
+	checked, err := p.validate(scanSpecs)
+	if err != nil {
+		return scan, err
+	}
+	fn, err := compile(checked.String("formula"), checked.Values("params"))
+	if err != nil {
+		return scan, err
+	}
+	eps := checked.Float("epsilon")
+
+	// Считаем вычисления функции при сканировании. Обработчику шагов
+	// передаются найденные корни, а не шаги их уточнения.
+	mt := newMeter(ctx)
+	ctx = WithStepHook(ctx, nil)
+	f := func(x float64) float64 {
+		scan.Evaluations++
+		mt.tick()
+		return fn(x)
+	}
+
+	// refine уточняет корень на отрезке со сменой знака выбранным методом
+	refine := func(a, b float64) IsolatedRoot {
+		root := IsolatedRoot{A: a, B: b, Kind: RootSignChange, Method: method}
+
+		input := make(Params, len(p))
+		maps.Copy(input, p)
+		switch {
+		case bracketed:
+			input["a"], input["b"] = a, b
+		case m.hasParam("x1"):
+			input["x0"], input["x1"] = a, b
+		default:
+			input["x0"] = (a + b) / 2
+		}
+
+		solver, err := NewSolver(method, input)
+		if err != nil {
+			root.Err = err
+			return root
+		}
+		root.Result, root.Err = solver.Calculate(ctx)
+
+		// Методы с начальным приближением могут уйти к соседнему корню
+		if root.Err == nil && (root.Result.Root < a-eps || root.Result.Root > b+eps) {
+			root.Err = fmt.Errorf("метод сошелся к x=%v за пределами отрезка локализации", root.Result.Root)
+		}
+		return root
+	}
+
+	xs, fs := sampleAdaptive(f, r.A, r.B, r.Samples)
+	n := len(xs)
+
+	for i := 0; i < n; i++ {
+		if err := mt.check(); err != nil {
+			return scan, err
+		}
+		x, fx := xs[i], fs[i]
+
+		switch {
+		case math.IsInf(fx, 0):
+			scan.addSingularity(Singularity{X: x, Kind: SingularityPole})
+		case fx == 0:
+			scan.Roots = record(mt, scan.Roots, IsolatedRoot{A: x, B: x, Kind: RootExact, Result: Result{Root: x}})
+		case i > 0 && i < n-1 && isTangentCandidate(fs[i-1], fx, fs[i+1]):
+			a, b := xs[i-1], xs[i+1]
+			res, crossed := goldenMinimum(f, a, b, math.Copysign(1, fx))
+			switch {
+			case crossed:
+				// В минимуме знак сменился: два близких корня по обе стороны от него
+				scan.Roots = record(mt, scan.Roots, refine(a, res.Root))
+				scan.Roots = record(mt, scan.Roots, refine(res.Root, b))
+			case math.Abs(f(res.Root)) <= eps:
+				scan.Roots = record(mt, scan.Roots, IsolatedRoot{A: a, B: b, Kind: RootTangent, Method: "golden_section", Result: res})
+			}
+		}
+
+		if i == n-1 || isBad(fx) || isBad(fs[i+1]) || fx*fs[i+1] >= 0 {
+			continue
+		}
+
+		a, b := xs[i], xs[i+1]
+		if kind, at := classifySignChange(f, a, b, fx, fs[i+1]); kind != "" {
+			scan.addSingularity(Singularity{X: at, Kind: kind})
+			continue
+		}
+		scan.Roots = record(mt, scan.Roots, refine(a, b))
+	}
+
+	// Уточнение последнего корня тоже могло быть прервано
+	return scan, mt.check()
+}
+
+// addSingularity добавляет особую точку, пропуская повторы одного и того же полюса
+// (полюс может попасть и в узел сетки, и в соседний отрезок со сменой знака)
+func (s *RootScan) addSingularity(sg Singularity) {
+	if k := len(s.Singularities); k > 0 {
+		last := s.Singularities[k-1]
+		if math.Abs(last.X-sg.X) <= 1e-9*math.Max(1, math.Abs(sg.X)) {
+			return
+		}
+	}
+	s.Singularities = append(s.Singularities, sg)
+}
+
+// sampleAdaptive вычисляет функцию на равномерной сетке из samples отрезков
+// и рекурсивно делит пополам отрезки, на которых график далек от прямой
+// или функция не определена в одной из точек
+func sampleAdaptive(f func(float64) float64, a, b float64, samples int) ([]float64, []float64) {
+	xs := make([]float64, 0, samples+1)
+	fs := make([]float64, 0, samples+1)
+
+	var subdivide func(x0, f0, x1, f1 float64, depth int)
+	subdivide = func(x0, f0, x1, f1 float64, depth int) {
+		xm := (x0 + x1) / 2
+		fm := f(xm)
+
+		// Отрезки, целиком лежащие вне области определения, не уточняем,
+		// а на границе области определения и у полюсов - сгущаем сетку
+		outside := isBad(f0) && isBad(f1) && isBad(fm)
+		smooth := !isBad(f0) && !isBad(f1) && !isBad(fm) &&
+			math.Abs(fm-(f0+f1)/2) <= scanCurvatureTol*(math.Abs(f0)+math.Abs(f1)+math.Abs(fm))
+		if depth >= scanMaxDepth || len(xs) >= maxScanPoints || smooth || outside {
+			xs, fs = append(xs, xm, x1), append(fs, fm, f1)
+			return
+		}
+		subdivide(x0, f0, xm, fm, depth+1)
+		subdivide(xm, fm, x1, f1, depth+1)
+	}
+
+	h := (b - a) / float64(samples)
+	x0, f0 := a, f(a)
+	xs, fs = append(xs, x0), append(fs, f0)
+	for i := 1; i <= samples; i++ {
+		x1 := a + float64(i)*h
+		if i == samples {
+			x1 = b
+		}
+		f1 := f(x1)
+		subdivide(x0, f0, x1, f1, 0)
+		x0, f0 = x1, f1
+	}
+
+	return xs, fs
+}
+
+// isTangentCandidate проверяет, что в средней из трех соседних точек |f|
+// достигает локального минимума, а знак функции не меняется
+func isTangentCandidate(fl, fm, fr float64) bool {
+	if isBad(fl) || isBad(fr) || fl*fm <= 0 || fm*fr <= 0 {
+		return false
+	}
+	return math.Abs(fm) < math.Abs(fl) && math.Abs(fm) <= math.Abs(fr)
+}
+
+// classifySignChange сужает отрезок со сменой знака делением пополам и
+// определяет, что находится внутри. Для корня |f| на концах стремится к нулю,
+// для полюса - растет, для конечного разрыва - остается порядка исходного.
+// Возвращает пустой вид, если на отрезке корень.
+func classifySignChange(f func(float64) float64, a, b, fa, fb float64) (string, float64) {
+	start := math.Max(math.Abs(fa), math.Abs(fb))
+
+	for range scanBisections {
+		mid := (a + b) / 2
+		if mid <= a || mid >= b {
+			break
+		}
+		fm := f(mid)
+		if isBad(fm) {
+			return SingularityPole, mid
+		}
+		if fm == 0 {
+			return "", mid
+		}
+		if fa*fm < 0 {
+			b, fb = mid, fm
+		} else {
+			a, fa = mid, fm
+		}
+	}
+
+	end := math.Min(math.Abs(fa), math.Abs(fb))
+	switch {
+	case end < scanRootShrink*start:
+		return "", (a + b) / 2
+	case end > start:
+		return SingularityPole, (a + b) / 2
+	default:
+		return SingularityJump, (a + b) / 2
+	}
+}
+
+// goldenMinimum ищет минимум функции sign*f на [a, b] методом золотого сечения.
+// Если по пути встретилась точка, где sign*f < 0, поиск прекращается и crossed = true:
+// функция сменила знак, и Root - точка между двумя корнями.
+func goldenMinimum(f func(float64) float64, a, b, sign float64) (res Result, crossed bool) {
+	g := func(x float64) float64 { return sign * f(x) }
+
+	x1 := a + goldenRatio*(b-a)
+	x2 := b - goldenRatio*(b-a)
+	g1, g2 := g(x1), g(x2)
+
+	for i := 1; i <= maxIter; i++ {
+		res.Iterations = i
+
+		if g1 < 0 || g2 < 0 {
+			res.Root = x1
+			if g2 < g1 {
+				res.Root = x2
+			}
+			return res, true
+		}
+
+		if g1 <= g2 {
+			b, x2, g2 = x2, x1, g1
+			x1 = a + goldenRatio*(b-a)
+			g1 = g(x1)
+		} else {
+			a, x1, g1 = x1, x2, g2
+			x2 = b - goldenRatio*(b-a)
+			g2 = g(x2)
+		}
+
+		res.Root = x1
+		if g2 < g1 {
+			res.Root = x2
+		}
+		res.Steps = append(res.Steps, Step{XPrev: res.Root, XNew: res.Root, Fx: sign * math.Min(g1, g2), A: a, B: b, Segment: true, Kind: StepGolden})
+
+		if b-a <= scanGoldenTol*math.Max(1, math.Abs(res.Root)) {
+			return res, false
+		}
+	}
+
+	return res, false
+}
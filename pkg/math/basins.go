@@ -0,0 +1,167 @@
+package math
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// BasinsRegion - прямоугольник комплексной плоскости и разрешение сетки начальных приближений
+type BasinsRegion struct {
+	ReMin, ReMax float64
+	ImMin, ImMax float64
+	Width        int // Количество точек по вещественной оси
+	Height       int // Количество точек по мнимой оси
+	MaxIter      int // Предел итераций для одной точки
+}
+
+// Basins - бассейны притяжения корней для метода Ньютона. Точки хранятся
+// построчно сверху вниз (первая строка соответствует ImMax), как в изображении.
+type Basins struct {
+	Region     BasinsRegion
+	Roots      []complex128 // Различные корни, к которым сошелся метод
+	Root       []int        // Индекс корня в Roots для каждой точки, -1 - метод не сошелся
+	Iterations []int        // Число итераций для каждой точки
+}
+
+// Параметры построения бассейнов: область, разрешение и предел итераций
+var basinsSpecs = []ParamSpec{
+	formulaParam, paramsParam,
+	{Name: "epsilon", Type: ParamNumber, Default: 1e-6, Description: "Требуемая точность"},
+	{Name: "re_min", Type: ParamNumber, Default: -2.0, Description: "Левая граница области по вещественной оси"},
+	{Name: "re_max", Type: ParamNumber, Default: 2.0, Description: "Правая граница области по вещественной оси"},
+	{Name: "im_min", Type: ParamNumber, Default: -2.0, Description: "Нижняя граница области по мнимой оси"},
+	{Name: "im_max", Type: ParamNumber, Default: 2.0, Description: "Верхняя граница области по мнимой оси"},
+	{Name: "width", Type: ParamNumber, Default: 600.0, Description: "Ширина изображения в точках"},
+	{Name: "height", Type: ParamNumber, Default: 600.0, Description: "Высота изображения в точках"},
+	{Name: "max_iter", Type: ParamNumber, Default: 50.0, Description: "Предел итераций для одной точки"},
+}
+
+// NewtonBasins проверяет параметры и строит бассейны притяжения метода Ньютона
+func NewtonBasins(ctx context.Context, p Params) (Basins, error) {
+	checked, err := p.validate(basinsSpecs)
+	if err != nil {
+		return Basins{}, err
+	}
+
+	c, err := NewComplexNewtonCalculator(checked.String("formula"), checked.Values("params"), 0, checked.Float("epsilon"))
+	if err != nil {
+		return Basins{}, err
+	}
+
+	return c.Basins(ctx, BasinsRegion{
+		ReMin:   checked.Float("re_min"),
+		ReMax:   checked.Float("re_max"),
+		ImMin:   checked.Float("im_min"),
+		ImMax:   checked.Float("im_max"),
+		Width:   int(checked.Float("width")),
+		Height:  int(checked.Float("height")),
+		MaxIter: int(checked.Float("max_iter")),
+	})
+}
+
+// Basins запускает метод Ньютона из каждой точки сетки и определяет,
+// к какому корню он сходится. Строки сетки считаются параллельно, у каждого
+// исполнителя свой meter, а бюджет вычислений общий. После отмены ctx или
+// исчерпания бюджета возвращается *InterruptedError.
+func (c *ComplexNewtonCalculator) Basins(ctx context.Context, r BasinsRegion) (Basins, error) {
+	if r.Width < 1 || r.Height < 1 || r.Width > maxBasinsSize || r.Height > maxBasinsSize {
+		return Basins{}, fmt.Errorf("размер изображения должен быть от 1 до %d точек по каждой оси", maxBasinsSize)
+	}
+	if !(r.ReMin < r.ReMax) || !(r.ImMin < r.ImMax) {
+		return Basins{}, fmt.Errorf("нижние границы области должны быть меньше верхних")
+	}
+	if r.MaxIter < 1 || r.MaxIter > maxBasinsIter {
+		return Basins{}, fmt.Errorf("предел итераций должен быть от 1 до %d", maxBasinsIter)
+	}
+
+	n := r.Width * r.Height
+	limits := make([]complex128, n)
+	converged := make([]bool, n)
+	b := Basins{Region: r, Root: make([]int, n), Iterations: make([]int, n)}
+
+	var (
+		mu          sync.Mutex
+		stopErr     *InterruptedError
+		evaluations int
+		stopped     atomic.Bool
+	)
+
+	rows := make(chan int)
+	var wg sync.WaitGroup
+	for range runtime.GOMAXPROCS(0) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mt := newMeter(ctx)
+			f, deriv := c.metered(mt)
+			defer func() {
+				mu.Lock()
+				evaluations += mt.evals
+				mu.Unlock()
+			}()
+
+			for row := range rows {
+				im := gridNode(r.ImMax, r.ImMin, row, r.Height)
+				for col := 0; col < r.Width && !stopped.Load(); col++ {
+					i := row*r.Width + col
+					z := complex(gridNode(r.ReMin, r.ReMax, col, r.Width), im)
+					root, iterations, _, err := c.solve(mt, f, deriv, z, r.MaxIter, nil)
+					var ie *InterruptedError
+					if errors.As(err, &ie) {
+						mu.Lock()
+						stopErr = ie
+						mu.Unlock()
+						stopped.Store(true)
+						break
+					}
+					limits[i], b.Iterations[i], converged[i] = root, iterations, err == nil
+				}
+			}
+		}()
+	}
+	for row := 0; row < r.Height && !stopped.Load(); row++ {
+		rows <- row
+	}
+	close(rows)
+	wg.Wait()
+	if stopErr != nil {
+		return Basins{}, &InterruptedError{Cause: stopErr.Cause, Status: stopErr.Status, Evaluations: evaluations}
+	}
+
+	// Объединяем пределы в различные корни. Корней обычно единицы, поэтому
+	// линейного поиска по уже найденным достаточно.
+	tol := math.Max(basinsRootTol, 100*c.Epsilon)
+	for i, z := range limits {
+		b.Root[i] = -1
+		if !converged[i] {
+			continue
+		}
+		for k, root := range b.Roots {
+			if cmplx.Abs(z-root) <= tol*math.Max(1, cmplx.Abs(root)) {
+				b.Root[i] = k
+				break
+			}
+		}
+		if b.Root[i] < 0 && len(b.Roots) < maxBasinsRoots {
+			b.Roots = append(b.Roots, z)
+			b.Root[i] = len(b.Roots) - 1
+		}
+	}
+
+	return b, nil
+}
+
+// gridNode возвращает k-й из n равноотстоящих узлов от from до to.
+// Единственный узел берется в середине отрезка.
+func gridNode(from, to float64, k, n int) float64 {
+	if n == 1 {
+		return (from + to) / 2
+	}
+	return from + float64(k)*(to-from)/float64(n-1)
+}
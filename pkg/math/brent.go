@@ -0,0 +1,177 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+func init() {
+	Register(Method{
+		Name:   "brent",
+		Title:  "Метод Брента",
+		Params: []ParamSpec{formulaParam, paramsParam, epsilonParam, aParam, bParam},
+		New: func(p Params) (Solver, error) {
+			return NewBrentMethodCalculator(p.String("formula"), p.Values("params"), p.Float("a"), p.Float("b"), p.Float("epsilon"))
+		},
+	})
+}
+
+// Типы подшагов комбинированных методов
+const (
+	StepBisection = "bisection" // Деление отрезка пополам
+	StepSecant    = "secant"    // Линейная интерполяция (секущая)
+	StepIQI       = "iqi"       // Обратная квадратичная интерполяция
+)
+
+// Машинный эпсилон для float64
+const machineEpsilon = 2.220446049250313e-16
+
+// BrentMethodCalculator реализует метод Брента: на каждом шаге пробуется
+// обратная квадратичная интерполяция или секущая, а если интерполяция
+// выводит за отрезок или сходится слишком медленно - выполняется деление пополам.
+// Поэтому метод сходится всегда, как дихотомия, но обычно сверхлинейно.
+type BrentMethodCalculator struct {
+	stopper
+
+	Func    func(float64) float64
+	A       float64
+	B       float64
+	Epsilon float64
+}
+
+func NewBrentMethodCalculator(funcStr string, params map[string]float64, a, b, epsilon float64) (*BrentMethodCalculator, error) {
+	fn, err := compile(funcStr, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BrentMethodCalculator{
+		Func:    fn,
+		A:       a,
+		B:       b,
+		Epsilon: epsilon,
+	}, nil
+}
+
+func (c *BrentMethodCalculator) Calculate(ctx context.Context) (Result, error) {
+	mt := newMeter(ctx)
+	f := mt.wrap(c.Func)
+
+	var res Result
+
+	a, b := c.A, c.B
+	fa, fb := f(a), f(b)
+
+	if isBad(fa) {
+		return res, fmt.Errorf("ошибка вычисления функции в точке a=%v", a)
+	}
+	if isBad(fb) {
+		return res, fmt.Errorf("ошибка вычисления функции в точке b=%v", b)
+	}
+	if fa*fb > 0 {
+		return res, fmt.Errorf("функция имеет одинаковые знаки на концах отрезка")
+	}
+
+	// b - лучшее приближение, a - предыдущее, [b, cc] всегда содержит корень.
+	// d - последний шаг, e - шаг перед ним.
+	cc, fc := b, fb
+	var d, e float64
+
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		if (fb > 0 && fc > 0) || (fb < 0 && fc < 0) {
+			cc, fc = a, fa
+			d = b - a
+			e = d
+		}
+		// b должна оставаться точкой с наименьшим |f|
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, cc = b, cc, b
+			fa, fb, fc = fb, fc, fb
+		}
+
+		tol := 2*machineEpsilon*math.Abs(b) + 0.5*c.Epsilon
+		xm := 0.5 * (cc - b)
+		if fb == 0 {
+			res.Root, res.StopReason = b, StopExact
+			return res, nil
+		}
+		if reason := c.Stop.own(math.Abs(xm) <= tol, StopBracket); reason != "" {
+			res.Root, res.StopReason = b, reason
+			return res, nil
+		}
+
+		kind := StepBisection
+		if math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			var p, q float64
+			s := fb / fa
+			if a == cc {
+				// Две точки - линейная интерполяция
+				kind = StepSecant
+				p = 2 * xm * s
+				q = 1 - s
+			} else {
+				// Три точки - обратная квадратичная интерполяция
+				kind = StepIQI
+				q = fa / fc
+				r := fb / fc
+				p = s * (2*xm*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			}
+			p = math.Abs(p)
+
+			// Интерполяция принимается, только если точка лежит внутри отрезка
+			// и шаг уменьшается достаточно быстро
+			if 2*p < math.Min(3*xm*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				kind = StepBisection
+				d = xm
+				e = d
+			}
+		} else {
+			d = xm
+			e = d
+		}
+
+		xPrev := b
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else {
+			b += math.Copysign(tol, xm)
+		}
+		fb = f(b)
+		if isBad(fb) {
+			return res, fmt.Errorf("ошибка вычисления функции в точке x=%v", b)
+		}
+
+		res.Steps = record(mt, res.Steps, Step{
+			XPrev:   xPrev,
+			XNew:    b,
+			Fx:      fb,
+			A:       math.Min(xPrev, cc),
+			B:       math.Max(xPrev, cc),
+			Segment: true,
+			Kind:    kind,
+		})
+
+		// Критерии запроса проверяются после шага, собственный - в начале итерации
+		if reason := c.Stop.check(math.Abs(b-xPrev), b, f); reason != "" {
+			res.Root, res.StopReason = b, reason
+			return res, nil
+		}
+	}
+
+	res.StopReason = StopMaxIter
+	return res, fmt.Errorf("превышено максимальное количество итераций")
+}
@@ -0,0 +1,127 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"gonum.org/v1/gonum/diff/fd"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func init() {
+	RegisterSystem(SystemMethod{
+		Name:   "broyden",
+		Title:  "Метод Бройдена",
+		Params: systemParams,
+		New: func(p Params) (SystemSolver, error) {
+			return NewBroydenCalculator(p.Strings("equations"), p.Strings("vars"), p.Values("params"), p.Vector("x0"), p.Float("epsilon"))
+		},
+	})
+}
+
+// BroydenCalculator реализует квазиньютоновский метод Бройдена. Матрица Якоби
+// вычисляется конечными разностями только в начальной точке, а дальше
+// обратная к ней матрица H уточняется по формуле Шермана - Моррисона:
+//
+//	s = x_n+1 - x_n,  y = F(x_n+1) - F(x_n),
+//	H_n+1 = H_n + (s - H_n y) s^T H_n / (s^T H_n y).
+//
+// Поэтому на шаге нужно одно вычисление F и ни одного решения линейной
+// системы, а сходимость сверхлинейная.
+type BroydenCalculator struct {
+	stopper
+
+	X0      []float64
+	Epsilon float64
+
+	sys *system
+}
+
+func NewBroydenCalculator(equations, vars []string, params map[string]float64, x0 []float64, epsilon float64) (*BroydenCalculator, error) {
+	sys, err := compileSystem(equations, vars, params, x0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BroydenCalculator{X0: x0, Epsilon: epsilon, sys: sys}, nil
+}
+
+func (c *BroydenCalculator) Calculate(ctx context.Context) (SystemResult, error) {
+	res := SystemResult{Info: map[string]any{"vars": c.sys.vars, "jacobian_mode": "numeric"}}
+	defer func() { res.Info["evaluations"] = c.sys.evaluations }()
+	mt := newMeter(ctx)
+	c.sys.mt = mt
+
+	n := len(c.X0)
+	x := slices.Clone(c.X0)
+	fx := make([]float64, n)
+	c.sys.eval(fx, x)
+	if isBadVector(fx) {
+		return res, fmt.Errorf("ошибка вычисления системы в начальном приближении")
+	}
+
+	j := mat.NewDense(n, n, nil)
+	fd.Jacobian(j, c.sys.eval, x, &fd.JacobianSettings{Formula: fd.Central, OriginValue: fx})
+	var h mat.Dense
+	if err := h.Inverse(j); err != nil {
+		if cond, ok := err.(mat.Condition); !ok || float64(cond) > 1/machineEpsilon {
+			return res, fmt.Errorf("матрица Якоби вырождена в начальном приближении")
+		}
+	}
+
+	fNew := make([]float64, n)
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		// s = -H F
+		var sv mat.VecDense
+		sv.MulVec(&h, mat.NewVecDense(n, fx))
+		sv.ScaleVec(-1, &sv)
+		s := sv.RawVector().Data
+
+		xNew := make([]float64, n)
+		floats.AddTo(xNew, x, s)
+		c.sys.eval(fNew, xNew)
+		if isBadVector(fNew) {
+			return res, fmt.Errorf("ошибка вычисления системы в точке %v", xNew)
+		}
+
+		residual := floats.Norm(fNew, 2)
+		res.Steps = record(mt, res.Steps, SystemStep{XPrev: x, XNew: xNew, Residual: residual, StepNorm: floats.Norm(s, 2)})
+
+		if reason := systemStop(c.Stop, s, xNew, residual, c.Epsilon); reason != "" {
+			res.Root, res.StopReason = xNew, reason
+			return res, nil
+		}
+
+		// Обновление обратной матрицы по Шерману - Моррисону
+		y := make([]float64, n)
+		floats.SubTo(y, fNew, fx)
+
+		var hy, sh mat.VecDense
+		hy.MulVec(&h, mat.NewVecDense(n, y))
+		sh.MulVec(h.T(), &sv)
+		denom := mat.Dot(&sv, &hy)
+		if math.Abs(denom) < machineEpsilon*floats.Norm(s, 2)*floats.Norm(hy.RawVector().Data, 2) {
+			return res, fmt.Errorf("вырожденное обновление матрицы в точке %v", xNew)
+		}
+
+		var diff mat.VecDense
+		diff.SubVec(&sv, &hy)
+		var update mat.Dense
+		update.Outer(1/denom, &diff, &sh)
+		h.Add(&h, &update)
+
+		x = xNew
+		copy(fx, fNew)
+	}
+
+	res.StopReason = StopMaxIter
+	return res, fmt.Errorf("превышено максимальное количество итераций")
+}
@@ -0,0 +1,107 @@
+package math
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// Причины прерывания вычисления до его завершения
+var (
+	ErrCancelled  = errors.New("вычисление отменено")
+	ErrTimeout    = errors.New("превышено время, отведенное на вычисление")
+	ErrEvalBudget = errors.New("превышен бюджет вычислений функции")
+)
+
+// Значения InterruptedError.Status
+const (
+	InterruptCancelled  = "cancelled"
+	InterruptTimeout    = "timeout"
+	InterruptEvalBudget = "eval_budget"
+)
+
+// InterruptedError - вычисление прервано отменой контекста, истечением
+// времени или исчерпанием бюджета вычислений. Результат, возвращенный вместе
+// с ошибкой, содержит шаги, выполненные к этому моменту.
+type InterruptedError struct {
+	Cause       error  // ErrCancelled, ErrTimeout или ErrEvalBudget
+	Status      string // InterruptCancelled, InterruptTimeout или InterruptEvalBudget
+	Evaluations int    // Сколько раз функция была вычислена в прерванном расчете
+}
+
+func (e *InterruptedError) Error() string {
+	if e.Evaluations == 0 {
+		return e.Cause.Error()
+	}
+	return fmt.Sprintf("%v (вычислений функции: %d)", e.Cause, e.Evaluations)
+}
+
+func (e *InterruptedError) Unwrap() error { return e.Cause }
+
+// evalBudget - общий для всего запроса предел числа вычислений функции.
+// Счетчик атомарный, потому что прогон по параметру и поиск всех корней
+// расходуют один бюджет на много решателей.
+type evalBudget struct {
+	limit int64
+	used  atomic.Int64
+}
+
+type evalBudgetKey struct{}
+
+// WithEvalBudget ограничивает число вычислений функции во всех расчетах,
+// запущенных с этим контекстом
+func WithEvalBudget(ctx context.Context, limit int) context.Context {
+	return context.WithValue(ctx, evalBudgetKey{}, &evalBudget{limit: int64(limit)})
+}
+
+// interrupted возвращает *InterruptedError, если контекст отменен или истек
+func interrupted(ctx context.Context, evaluations int) error {
+	switch err := ctx.Err(); {
+	case err == nil:
+		return nil
+	case errors.Is(err, context.DeadlineExceeded):
+		return &InterruptedError{Cause: ErrTimeout, Status: InterruptTimeout, Evaluations: evaluations}
+	default:
+		return &InterruptedError{Cause: ErrCancelled, Status: InterruptCancelled, Evaluations: evaluations}
+	}
+}
+
+// meter считает вычисления функции в одном расчете и проверяет, можно ли
+// его продолжать. Калькуляторы создают его в начале Calculate, вызывают
+// check на каждой итерации и добавляют шаги через record.
+type meter struct {
+	ctx    context.Context
+	budget *evalBudget
+	onStep StepFunc
+	evals  int
+}
+
+func newMeter(ctx context.Context) *meter {
+	budget, _ := ctx.Value(evalBudgetKey{}).(*evalBudget)
+	return &meter{ctx: ctx, budget: budget, onStep: stepHook(ctx)}
+}
+
+// tick учитывает одно вычисление функции
+func (m *meter) tick() {
+	m.evals++
+	if m.budget != nil {
+		m.budget.used.Add(1)
+	}
+}
+
+// wrap возвращает f, учитывающую каждое свое вычисление
+func (m *meter) wrap(f func(float64) float64) func(float64) float64 {
+	return func(x float64) float64 {
+		m.tick()
+		return f(x)
+	}
+}
+
+// check возвращает *InterruptedError, если расчет нужно прервать
+func (m *meter) check() error {
+	if m.budget != nil && m.budget.used.Load() > m.budget.limit {
+		return &InterruptedError{Cause: ErrEvalBudget, Status: InterruptEvalBudget, Evaluations: m.evals}
+	}
+	return interrupted(m.ctx, m.evals)
+}
@@ -0,0 +1,97 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+func init() {
+	Register(Method{
+		Name:   "chebyshev",
+		Title:  "Метод Чебышёва",
+		Params: []ParamSpec{formulaParam, paramsParam, epsilonParam, x0Param},
+		New: func(p Params) (Solver, error) {
+			return NewChebyshevMethodCalculator(p.String("formula"), p.Values("params"), p.Float("x0"), p.Float("epsilon"))
+		},
+	})
+}
+
+// ChebyshevMethodCalculator реализует метод Чебышёва третьего порядка:
+//
+//	x_n+1 = x_n - f/f' * (1 + f f'' / (2 f'^2))
+type ChebyshevMethodCalculator struct {
+	stopper
+
+	Func    func(float64) float64
+	X0      float64
+	Epsilon float64
+
+	// Производные f'(x) и f''(x)
+	derivs derivatives
+}
+
+func NewChebyshevMethodCalculator(funcStr string, params map[string]float64, x0, epsilon float64) (*ChebyshevMethodCalculator, error) {
+	fn, err := compile(funcStr, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChebyshevMethodCalculator{
+		Func:    fn,
+		derivs:  newDerivatives(funcStr, params, fn),
+		X0:      x0,
+		Epsilon: epsilon,
+	}, nil
+}
+
+func (c *ChebyshevMethodCalculator) Calculate(ctx context.Context) (Result, error) {
+	mt := newMeter(ctx)
+	f := mt.wrap(c.Func)
+	derivs := c.derivs.metered(mt)
+
+	res := Result{Info: c.derivs.info(true)}
+	x := c.X0
+
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		fx := f(x)
+		if isBad(fx) {
+			return res, fmt.Errorf("ошибка вычисления функции в точке x=%v", x)
+		}
+		if fx == 0 {
+			res.Root, res.StopReason = x, StopExact
+			return res, nil
+		}
+
+		d1 := derivs.first(x)
+		if math.Abs(d1) < 1e-10 {
+			// Вблизи кратного корня производная вырождается вместе с невязкой
+			if math.Abs(fx) < c.Epsilon {
+				res.Root, res.StopReason = x, StopResidual
+				return res, nil
+			}
+			return res, fmt.Errorf("производная равна нулю в точке x=%v", x)
+		}
+		d2 := derivs.second(x)
+
+		u := fx / d1
+		xNew := x - u*(1+u*d2/(2*d1))
+		res.Steps = record(mt, res.Steps, Step{XPrev: x, XNew: xNew, Fx: fx})
+
+		step := math.Abs(xNew - x)
+		if reason := c.Stop.done(step < c.Epsilon, StopStep, step, xNew, f); reason != "" {
+			res.Root, res.StopReason = xNew, reason
+			return res, nil
+		}
+		x = xNew
+	}
+
+	res.Root = x
+	res.StopReason = StopMaxIter
+	return res, fmt.Errorf("превышено максимальное количество итераций")
+}
@@ -0,0 +1,108 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+func init() {
+	Register(Method{
+		Name:   "chord",
+		Title:  "Метод хорд (ложного положения)",
+		Params: []ParamSpec{formulaParam, paramsParam, epsilonParam, aParam, bParam},
+		New: func(p Params) (Solver, error) {
+			return NewChordMethodCalculator(p.String("formula"), p.Values("params"), p.Float("a"), p.Float("b"), p.Float("epsilon"))
+		},
+	})
+}
+
+// ChordMethodCalculator реализует метод хорд (regula falsi): отрезок делится
+// не пополам, а в точке пересечения хорды между концами отрезка с осью абсцисс.
+type ChordMethodCalculator struct {
+	stopper
+
+	Func    func(float64) float64
+	A       float64
+	B       float64
+	Epsilon float64
+}
+
+func NewChordMethodCalculator(funcStr string, params map[string]float64, a, b, epsilon float64) (*ChordMethodCalculator, error) {
+	fn, err := compile(funcStr, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChordMethodCalculator{
+		Func:    fn,
+		A:       a,
+		B:       b,
+		Epsilon: epsilon,
+	}, nil
+}
+
+func (c *ChordMethodCalculator) Calculate(ctx context.Context) (Result, error) {
+	mt := newMeter(ctx)
+	f := mt.wrap(c.Func)
+
+	var res Result
+	a := c.A
+	b := c.B
+
+	fa := f(a)
+	fb := f(b)
+
+	if isBad(fa) {
+		return res, fmt.Errorf("ошибка вычисления функции в точке a=%v", a)
+	}
+	if isBad(fb) {
+		return res, fmt.Errorf("ошибка вычисления функции в точке b=%v", b)
+	}
+
+	if fa*fb > 0 {
+		return res, fmt.Errorf("функция имеет одинаковые знаки на концах отрезка")
+	}
+
+	// Предыдущая точка пересечения хорды, нужна для проверки точности
+	xPrev := math.NaN()
+
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		// Точка пересечения хорды с осью абсцисс
+		x := a - fa*(b-a)/(fb-fa)
+		fx := f(x)
+
+		if isBad(fx) {
+			return res, fmt.Errorf("ошибка вычисления функции в точке x=%v", x)
+		}
+
+		res.Steps = record(mt, res.Steps, Step{XPrev: a, XNew: x, Fx: fx, A: a, B: b, Segment: true})
+
+		// На первой итерации xPrev = NaN, и шаг не определен
+		step := math.Abs(x - xPrev)
+		reason := c.Stop.done(step < c.Epsilon, StopStep, step, x, f)
+		if fx == 0 {
+			reason = StopExact
+		}
+		if reason != "" {
+			res.Root, res.StopReason = x, reason
+			return res, nil
+		}
+
+		// Оставляем ту часть отрезка, на концах которой функция меняет знак
+		if fa*fx <= 0 {
+			b, fb = x, fx
+		} else {
+			a, fa = x, fx
+		}
+		xPrev = x
+	}
+
+	res.StopReason = StopMaxIter
+	return res, fmt.Errorf("превышено максимальное количество итераций")
+}
@@ -0,0 +1,173 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"slices"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/mathutils"
+)
+
+// ComplexStep - шаг комплексного метода Ньютона
+type ComplexStep struct {
+	ZPrev complex128 // Текущее приближение z_n
+	ZNew  complex128 // Следующее приближение z_n+1
+	Fz    complex128 // f(z_n)
+}
+
+// ComplexResult - результат комплексного метода Ньютона
+type ComplexResult struct {
+	Steps      []ComplexStep
+	Root       complex128
+	Iterations int
+	StopReason string
+	Info       map[string]any
+}
+
+// Параметры комплексного метода Ньютона: начальное приближение z0 = x0 + i*y0
+// и критерии остановки. Длиной шага считается |z_n+1 - z_n|, невязкой - |f(z_n+1)|.
+var complexNewtonSpecs = slices.Concat([]ParamSpec{
+	formulaParam, paramsParam, epsilonParam,
+	{Name: "x0", Type: ParamNumber, Required: true, Description: "Вещественная часть начального приближения"},
+	{Name: "y0", Type: ParamNumber, Default: 0.0, Description: "Мнимая часть начального приближения"},
+}, stopParams)
+
+// ComplexNewton проверяет параметры и запускает комплексный метод Ньютона
+func ComplexNewton(ctx context.Context, p Params) (ComplexResult, error) {
+	checked, err := p.validate(complexNewtonSpecs)
+	if err != nil {
+		return ComplexResult{}, err
+	}
+
+	z0 := complex(checked.Float("x0"), checked.Float("y0"))
+	c, err := NewComplexNewtonCalculator(checked.String("formula"), checked.Values("params"), z0, checked.Float("epsilon"))
+	if err != nil {
+		return ComplexResult{}, err
+	}
+	stop, err := stopCriteria(checked)
+	if err != nil {
+		return ComplexResult{}, err
+	}
+	c.setStop(stop)
+	return c.Calculate(ctx)
+}
+
+// ComplexNewtonCalculator - метод Ньютона на комплексной плоскости:
+// z_{n+1} = z_n - f(z_n)/f'(z_n). В отличие от вещественного метода, он
+// находит и комплексные корни, а из вещественного начального приближения
+// может уйти с вещественной оси.
+type ComplexNewtonCalculator struct {
+	stopper
+
+	Func    func(complex128) complex128
+	Deriv   func(complex128) complex128
+	Z0      complex128
+	Epsilon float64
+
+	derivFormula string // Запись символьной производной, пустая для численной
+}
+
+func NewComplexNewtonCalculator(funcStr string, params map[string]float64, z0 complex128, epsilon float64) (*ComplexNewtonCalculator, error) {
+	prog, err := mathutils.ParseFormula(funcStr, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := prog.CheckComplex(); err != nil {
+		return nil, err
+	}
+
+	c := &ComplexNewtonCalculator{
+		Func:    prog.EvalComplex1,
+		Z0:      z0,
+		Epsilon: epsilon,
+	}
+	c.Deriv = func(z complex128) complex128 { return complexDerivative(c.Func, z) }
+
+	// Символьная производная, если ее удалось построить и она определена
+	// для комплексных чисел (производная abs содержит sign, у которого аналога нет)
+	tree, _ := mathutils.ParseTree(funcStr)
+	if d, err := mathutils.Derivative(tree, "x"); err == nil {
+		if dprog, err := mathutils.Compile(d, d.String(), params, "x"); err == nil && dprog.CheckComplex() == nil {
+			c.Deriv, c.derivFormula = dprog.EvalComplex1, d.String()
+		}
+	}
+
+	return c, nil
+}
+
+// complexDerivative - центральная разность вдоль вещественной оси.
+// Для аналитической функции производная не зависит от направления.
+func complexDerivative(f func(complex128) complex128, z complex128) complex128 {
+	h := complexDiffStep * math.Max(1, cmplx.Abs(z))
+	return (f(z+complex(h, 0)) - f(z-complex(h, 0))) / complex(2*h, 0)
+}
+
+// metered возвращает f и f', учитывающие вычисления в mt: численная
+// производная учитывает оба вычисления f, символьная - одно вычисление
+func (c *ComplexNewtonCalculator) metered(mt *meter) (f, deriv func(complex128) complex128) {
+	f = func(z complex128) complex128 {
+		mt.tick()
+		return c.Func(z)
+	}
+	if c.derivFormula == "" {
+		return f, func(z complex128) complex128 { return complexDerivative(f, z) }
+	}
+	return f, func(z complex128) complex128 {
+		mt.tick()
+		return c.Deriv(z)
+	}
+}
+
+func (c *ComplexNewtonCalculator) Calculate(ctx context.Context) (ComplexResult, error) {
+	res := ComplexResult{Info: map[string]any{"derivative_mode": "numeric"}}
+	if c.derivFormula != "" {
+		res.Info["derivative_mode"] = "symbolic"
+		res.Info["derivative"] = c.derivFormula
+	}
+
+	mt := newMeter(ctx)
+	f, deriv := c.metered(mt)
+	root, iterations, reason, err := c.solve(mt, f, deriv, c.Z0, c.Stop.maxIter(), &res.Steps)
+	res.Root, res.Iterations, res.StopReason = root, iterations, reason
+	return res, err
+}
+
+// solve выполняет итерации из точки z с функцией и производной, полученными
+// от metered, и возвращает приближение, число итераций и причину остановки.
+// Шаги записываются в steps, если он не nil: при построении бассейнов
+// траектории миллионов точек не нужны.
+func (c *ComplexNewtonCalculator) solve(mt *meter, f, deriv func(complex128) complex128, z complex128, limit int, steps *[]ComplexStep) (complex128, int, string, error) {
+	for i := 1; i <= limit; i++ {
+		if err := mt.check(); err != nil {
+			return z, i - 1, "", err
+		}
+		fz := f(z)
+		dfz := deriv(z)
+
+		if cmplx.IsNaN(fz) || cmplx.IsInf(fz) {
+			return z, i, "", fmt.Errorf("ошибка вычисления функции в точке z=%v", z)
+		}
+		if cmplx.Abs(dfz) < machineEpsilon {
+			if cmplx.Abs(fz) < c.Epsilon {
+				return z, i, StopResidual, nil
+			}
+			return z, i, "", fmt.Errorf("производная близка к нулю в точке z=%v", z)
+		}
+
+		zNew := z - fz/dfz
+		if steps != nil {
+			*steps = record(mt, *steps, ComplexStep{ZPrev: z, ZNew: zNew, Fz: fz})
+		}
+
+		step := cmplx.Abs(zNew - z)
+		residual := func(float64) float64 { return cmplx.Abs(f(zNew)) }
+		if reason := c.Stop.done(step < c.Epsilon*math.Max(1, cmplx.Abs(zNew)), StopStep, step, cmplx.Abs(zNew), residual); reason != "" {
+			return zNew, i, reason, nil
+		}
+		z = zNew
+	}
+
+	return z, limit, StopMaxIter, fmt.Errorf("превышено максимальное количество итераций")
+}
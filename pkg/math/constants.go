@@ -6,4 +6,74 @@ const (
 	// Зашиваем жесткий предел, чтобы защитить сервер от зависания
 	// на нерешаемых уравнениях.
 	maxIter = 10000
+
+	// Максимальное количество точек в одном прогоне по параметру (Sweep)
+	maxSweepPoints = 1000
+
+	// Параметры сканирования отрезка при поиске всех корней (AllRoots):
+	// число точек начальной сетки по умолчанию и предел для запроса,
+	// глубина адаптивного деления и общий предел числа точек
+	defaultScanSamples = 200
+	maxScanSamples     = 10000
+	scanMaxDepth       = 6
+	maxScanPoints      = 100000
+
+	// Допустимое отклонение графика от прямой (относительно |f|),
+	// при котором отрезок сетки не делится дальше
+	scanCurvatureTol = 0.1
+
+	// Число делений пополам при проверке смены знака и доля, во сколько раз
+	// должен уменьшиться |f|, чтобы смена знака считалась корнем, а не разрывом
+	scanBisections = 200
+	scanRootShrink = 1e-6
+
+	// Относительная ширина отрезка, до которой сужается поиск минимума |f|
+	scanGoldenTol = 1e-10
+
+	// Наибольшая кратность корня, которую использует модифицированный метод
+	// Ньютона. Большие оценки кратности получаются вдали от корня и приводят
+	// к слишком длинным шагам.
+	maxMultiplicity = 10
+
+	// Максимальное количество итераций метода Аберта - Эрлиха.
+	// Метод сходится кубически, поэтому предел намного меньше maxIter,
+	// а траектория всех корней остается небольшой.
+	maxPolyIter = 500
+
+	// Сдвиг угла начальных приближений метода Аберта - Эрлиха (в радианах)
+	aberthAngleShift = 0.4
+
+	// Шаг центральной разности для комплексной производной (относительно |z|)
+	complexDiffStep = 1e-6
+
+	// Ограничения построения бассейнов притяжения: размер изображения по
+	// каждой оси, предел итераций в одной точке и число различимых корней
+	maxBasinsSize  = 2048
+	maxBasinsIter  = 500
+	maxBasinsRoots = 64
+
+	// Относительное расстояние, на котором пределы итераций считаются одним корнем
+	basinsRootTol = 1e-6
+
+	// Максимальное число уравнений в системе
+	maxSystemSize = 20
+
+	// Максимальный порядок системы линейных уравнений. Прямые методы хранят
+	// матрицу после каждого шага, поэтому объем ответа растет как n³.
+	maxLinearSize = 50
+
+	// Максимальное число шагов сетки при решении задачи Коши и допуск
+	// (относительно числа шагов), с которым (b - a) / h считается целым
+	maxODESteps = 100000
+	odeGridTol  = 1e-9
+
+	// Длина шага (относительно |x|), ниже которой шаги считаются шумом
+	// округления и не используются для оценки порядка сходимости
+	diagNoiseFloor = 1e-12
+
+	// Предварительная проверка простой итерации: число точек отрезка, в которых
+	// вычисляются φ и φ', и полуширина окрестности x0 (относительно max(1, |x0|)),
+	// если отрезок не задан
+	contractionSamples = 1000
+	contractionRadius  = 0.5
 )
@@ -0,0 +1,90 @@
+package math
+
+import (
+	"fmt"
+	"math"
+)
+
+// Выводы предварительной проверки простой итерации
+const (
+	VerdictConverges  = "converges"   // Условия теоремы о сжимающем отображении выполнены
+	VerdictMayDiverge = "may_diverge" // Достаточные условия не выполнены, сходимость не гарантирована
+)
+
+// ContractionCheck - проверка условий теоремы о сжимающем отображении для
+// x = φ(x) на отрезке [A, B]: если φ отображает отрезок в себя и
+// q = max|φ'(x)| < 1, итерации из любой точки отрезка сходятся к единственному
+// корню, а погрешность после n шагов не больше q^n/(1-q)·|x1 - x0|.
+type ContractionCheck struct {
+	A, B     float64
+	Q        float64 // max|φ'(x)| на отрезке
+	PhiMin   float64 // Наименьшее значение φ на отрезке
+	PhiMax   float64 // Наибольшее значение φ на отрезке
+	MapsInto bool    // φ([A, B]) ⊂ [A, B]
+	Verdict  string
+	Reason   string // Объяснение вывода
+
+	// Априорная оценка числа итераций до точности epsilon, -1 если q >= 1:
+	//	n >= ln(ε(1-q)/|x1 - x0|) / ln q
+	Iterations int
+}
+
+// CheckContraction проверяет условия сходимости простой итерации, вычисляя φ и φ'
+// в равномерно расположенных точках отрезка [a, b]
+func CheckContraction(phi, dphi func(float64) float64, x0, a, b, epsilon float64) ContractionCheck {
+	c := ContractionCheck{A: a, B: b, PhiMin: math.Inf(1), PhiMax: math.Inf(-1), Iterations: -1, Verdict: VerdictMayDiverge}
+
+	for i := 0; i <= contractionSamples; i++ {
+		x := a + (b-a)*float64(i)/contractionSamples
+		v, d := phi(x), dphi(x)
+		if isBad(v) || isBad(d) {
+			c.Q, c.PhiMin, c.PhiMax = math.NaN(), math.NaN(), math.NaN()
+			c.Reason = fmt.Sprintf("φ или φ' не определена в точке x=%.6g отрезка", x)
+			return c
+		}
+		c.Q = math.Max(c.Q, math.Abs(d))
+		c.PhiMin = math.Min(c.PhiMin, v)
+		c.PhiMax = math.Max(c.PhiMax, v)
+	}
+	c.MapsInto = c.PhiMin >= a && c.PhiMax <= b
+
+	if c.Q < 1 {
+		step := math.Abs(phi(x0) - x0)
+		c.Iterations = 0
+		if step > 0 && c.Q > 0 {
+			c.Iterations = max(0, int(math.Ceil(math.Log(epsilon*(1-c.Q)/step)/math.Log(c.Q))))
+		}
+	}
+
+	switch {
+	case c.Q >= 1:
+		c.Reason = fmt.Sprintf("q = max|φ'(x)| = %.4g >= 1, отображение не сжимающее", c.Q)
+	case !c.MapsInto:
+		c.Reason = fmt.Sprintf("q = %.4g < 1, но φ переводит отрезок [%.6g, %.6g] в [%.6g, %.6g], выходя за его пределы", c.Q, a, b, c.PhiMin, c.PhiMax)
+	case x0 < a || x0 > b:
+		c.Reason = fmt.Sprintf("начальное приближение x0 = %.6g лежит вне отрезка [%.6g, %.6g]", x0, a, b)
+	default:
+		c.Verdict = VerdictConverges
+		c.Reason = fmt.Sprintf("q = %.4g < 1 и φ отображает [%.6g, %.6g] в себя", c.Q, a, b)
+	}
+
+	return c
+}
+
+// info возвращает результаты проверки для ответа
+func (c ContractionCheck) info() map[string]any {
+	info := map[string]any{
+		"contraction_interval": fmt.Sprintf("[%.6g, %.6g]", c.A, c.B),
+		"maps_into_interval":   c.MapsInto,
+		"verdict":              c.Verdict,
+		"verdict_reason":       c.Reason,
+	}
+	if !isBad(c.Q) {
+		info["contraction_q"] = c.Q
+		info["phi_range"] = fmt.Sprintf("[%.6g, %.6g]", c.PhiMin, c.PhiMax)
+	}
+	if c.Iterations >= 0 {
+		info["apriori_iterations"] = c.Iterations
+	}
+	return info
+}
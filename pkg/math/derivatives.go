@@ -0,0 +1,94 @@
+package math
+
+import (
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/mathutils"
+	"gonum.org/v1/gonum/diff/fd"
+)
+
+// derivative численно вычисляет f'(x) центральной разностью
+func derivative(f func(float64) float64, x float64) float64 {
+	return fd.Derivative(f, x, &fd.Settings{Formula: fd.Central})
+}
+
+// secondDerivative численно вычисляет вторую производную центральной разностью второго порядка
+func secondDerivative(f func(float64) float64, x float64) float64 {
+	return fd.Derivative(f, x, &fd.Settings{Formula: fd.Central2nd})
+}
+
+// derivatives - первая и вторая производные функции. Производные строятся
+// символьно по дереву формулы; если это не удалось, используются конечные разности.
+type derivatives struct {
+	first  func(float64) float64
+	second func(float64) float64
+
+	// Исходная функция для конечных разностей
+	f func(float64) float64
+
+	// Записи символьных производных, пустые для численных
+	firstFormula  string
+	secondFormula string
+}
+
+func newDerivatives(formula string, params map[string]float64, f func(float64) float64) derivatives {
+	d := derivatives{
+		first:  func(x float64) float64 { return derivative(f, x) },
+		second: func(x float64) float64 { return secondDerivative(f, x) },
+		f:      f,
+	}
+
+	tree, err := mathutils.ParseTree(formula)
+	if err != nil {
+		return d
+	}
+
+	d1, err := mathutils.Derivative(tree, "x")
+	if err != nil {
+		return d
+	}
+	if prog, err := mathutils.Compile(d1, d1.String(), params, "x"); err == nil {
+		d.first, d.firstFormula = prog.Eval1, d1.String()
+	}
+
+	d2, err := mathutils.Derivative(d1, "x")
+	if err != nil {
+		return d
+	}
+	if prog, err := mathutils.Compile(d2, d2.String(), params, "x"); err == nil {
+		d.second, d.secondFormula = prog.Eval1, d2.String()
+	}
+
+	return d
+}
+
+// metered возвращает производные, учитывающие вычисления в mt. Численная
+// производная учитывает каждое вычисление f в разностной формуле,
+// символьная считается одним вычислением.
+func (d derivatives) metered(mt *meter) derivatives {
+	m := d
+	f := mt.wrap(d.f)
+	if d.firstFormula == "" {
+		m.first = func(x float64) float64 { return derivative(f, x) }
+	} else {
+		m.first = mt.wrap(d.first)
+	}
+	if d.secondFormula == "" {
+		m.second = func(x float64) float64 { return secondDerivative(f, x) }
+	} else {
+		m.second = mt.wrap(d.second)
+	}
+	return m
+}
+
+// info возвращает сведения о производных для ответа. withSecond - нужна ли
+// в ответе вторая производная (ее используют только методы третьего порядка).
+func (d derivatives) info(withSecond bool) map[string]any {
+	info := map[string]any{"derivative_mode": "numeric"}
+	if d.firstFormula != "" {
+		info["derivative_mode"] = "symbolic"
+		info["derivative"] = d.firstFormula
+	}
+	if withSecond && d.secondFormula != "" {
+		info["second_derivative"] = d.secondFormula
+	}
+	return info
+}
@@ -0,0 +1,159 @@
+package math
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+// Способы оценки погрешности найденного корня
+const (
+	BoundBracket     = "bracket"     // Длина отрезка, на котором гарантированно лежит корень
+	BoundContraction = "contraction" // q/(1-q)·|x_n - x_n-1| для сжимающего отображения, q ≈ |φ'(x_n)|
+	BoundNewton      = "newton"      // |f(x_n)| / |f'(x_n)| - оценка для простого корня
+)
+
+// StepDiagnostics - диагностика одного шага. Неизвестные величины равны NaN.
+type StepDiagnostics struct {
+	Residual   float64 // Невязка |f(x_n)| (для простой итерации |φ(x_n) - x_n|)
+	StepSize   float64 // |x_n - x_n-1|
+	ErrorBound float64 // Апостериорная оценка |x_n - x*|
+	Order      float64 // Оценка порядка сходимости по трем последним шагам
+}
+
+// Diagnostics - сведения о том, как метод сходился на самом деле. Порядок p
+// и константа C определяются из соотношения e_n+1 ≈ C·e_n^p, где в качестве
+// погрешностей e_n берутся длины шагов |x_n+1 - x_n|:
+//
+//	p ≈ ln(e_n+1/e_n) / ln(e_n/e_n-1),  C ≈ e_n+1 / e_n^p.
+type Diagnostics struct {
+	Order      float64 // Эмпирический порядок сходимости p (NaN, если шагов мало)
+	Rate       float64 // Асимптотическая константа C
+	ErrorBound float64 // Оценка погрешности последнего приближения
+	BoundKind  string  // Способ оценки погрешности
+	Steps      []StepDiagnostics
+}
+
+// diagnosedSolver дополняет результат любого метода диагностикой сходимости
+type diagnosedSolver struct {
+	Solver
+	method Method
+	f      func(float64) float64
+}
+
+// diagnosticFuncer реализуют методы, у которых функция для диагностики отличается
+// от формулы запроса (простая итерация, построившая φ по f)
+type diagnosticFuncer interface {
+	diagnosticFunc() func(float64) float64
+}
+
+func (s *diagnosedSolver) Calculate(ctx context.Context) (Result, error) {
+	res, err := s.Solver.Calculate(ctx)
+	if len(res.Steps) == 0 {
+		return res, err
+	}
+
+	// Прерванный расчет не продолжаем ни на одно вычисление функции
+	var ie *InterruptedError
+	if errors.As(err, &ie) {
+		res.Diagnostics = Diagnostics{Order: math.NaN(), Rate: math.NaN(), ErrorBound: math.NaN()}
+		return res, err
+	}
+
+	f := s.f
+	if d, ok := s.Solver.(diagnosticFuncer); ok {
+		f = d.diagnosticFunc()
+	}
+	res.Diagnostics = diagnose(s.method, newMeter(ctx).wrap(f), res.Steps)
+	return res, err
+}
+
+// bracketed проверяет, что метод работает на отрезке со сменой знака:
+// границы a и b для него обязательны (у простой итерации они лишь уточняют
+// отрезок проверки сходимости)
+func (m Method) bracketed() bool {
+	required := 0
+	for _, spec := range m.Params {
+		if (spec.Name == "a" || spec.Name == "b") && spec.Required {
+			required++
+		}
+	}
+	return required == 2
+}
+
+// diagnose вычисляет невязки, длины шагов, оценки погрешности и порядок сходимости
+func diagnose(m Method, f func(float64) float64, steps []Step) Diagnostics {
+	d := Diagnostics{Order: math.NaN(), Rate: math.NaN(), BoundKind: BoundNewton, Steps: make([]StepDiagnostics, len(steps))}
+	if m.FixedPoint {
+		d.BoundKind = BoundContraction
+	}
+
+	xPrev := steps[0].XPrev
+	for i, step := range steps {
+		x := step.XNew
+		sd := StepDiagnostics{StepSize: math.Abs(x - xPrev), ErrorBound: math.NaN(), Order: math.NaN()}
+
+		fx := f(x)
+		switch d.BoundKind {
+		case BoundContraction:
+			sd.Residual = math.Abs(fx - x)
+			if q := math.Abs(derivative(f, x)); q < 1 {
+				sd.ErrorBound = q / (1 - q) * sd.StepSize
+			}
+		default:
+			sd.Residual = math.Abs(fx)
+			if df := math.Abs(derivative(f, x)); df > 0 {
+				sd.ErrorBound = sd.Residual / df
+			}
+		}
+		if isBad(sd.ErrorBound) {
+			sd.ErrorBound = math.NaN()
+		}
+
+		// У интервальных методов корень лежит на текущем отрезке. Метод хорд
+		// и метод Риддерса долго не сужают его с одной стороны, поэтому берется
+		// меньшая из двух оценок, а в BoundKind - способ для последнего шага.
+		if m.bracketed() {
+			d.BoundKind = BoundNewton
+			if width := step.B - step.A; step.Segment && !(sd.ErrorBound <= width) {
+				sd.ErrorBound = width
+				d.BoundKind = BoundBracket
+			}
+		}
+
+		if i >= 2 {
+			sd.Order = convergenceOrder(d.Steps[i-2].StepSize, d.Steps[i-1].StepSize, sd.StepSize)
+		}
+
+		d.Steps[i] = sd
+		xPrev = x
+	}
+	d.ErrorBound = d.Steps[len(steps)-1].ErrorBound
+
+	// Порядок берем по последним шагам, которые еще не упираются в погрешность
+	// округления: иначе последние длины шагов - шум, и оценка p бессмысленна
+	for i := len(steps) - 1; i >= 2; i-- {
+		noise := diagNoiseFloor * math.Max(1, math.Abs(steps[i].XNew))
+		e0, e1, e2 := d.Steps[i-2].StepSize, d.Steps[i-1].StepSize, d.Steps[i].StepSize
+		if e0 <= noise || e1 <= noise || e2 <= noise || isBad(d.Steps[i].Order) {
+			continue
+		}
+		d.Order = d.Steps[i].Order
+		d.Rate = e2 / math.Pow(e1, d.Order)
+		break
+	}
+
+	return d
+}
+
+// convergenceOrder оценивает порядок сходимости по трем последовательным длинам шагов
+func convergenceOrder(e0, e1, e2 float64) float64 {
+	if e0 <= 0 || e1 <= 0 || e2 <= 0 || e0 == e1 {
+		return math.NaN()
+	}
+	p := math.Log(e2/e1) / math.Log(e1/e0)
+	if isBad(p) {
+		return math.NaN()
+	}
+	return p
+}
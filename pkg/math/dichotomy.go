@@ -1,27 +1,33 @@
 package math
 
 import (
+	"context"
 	"fmt"
 	"math"
-
-	"github.com/GeorgeTyupin/numerical_methods/pkg/math/mathutils"
-	"github.com/Knetic/govaluate"
 )
 
-type DichotomyStep struct {
-	A float64
-	B float64
+func init() {
+	Register(Method{
+		Name:   "dichotomy",
+		Title:  "Метод дихотомии (половинного деления)",
+		Params: []ParamSpec{formulaParam, paramsParam, epsilonParam, aParam, bParam},
+		New: func(p Params) (Solver, error) {
+			return NewDichotomyMethodCalculator(p.String("formula"), p.Values("params"), p.Float("a"), p.Float("b"), p.Float("epsilon"))
+		},
+	})
 }
 
 type DichotomyMethodCalculator struct {
-	Func    *govaluate.EvaluableExpression
+	stopper
+
+	Func    func(float64) float64
 	A       float64
 	B       float64
 	Epsilon float64
 }
 
-func NewDichotomyMethodCalculator(funcStr string, a, b, epsilon float64) (*DichotomyMethodCalculator, error) {
-	fn, err := mathutils.ParseFormula(funcStr)
+func NewDichotomyMethodCalculator(funcStr string, params map[string]float64, a, b, epsilon float64) (*DichotomyMethodCalculator, error) {
+	fn, err := compile(funcStr, params)
 	if err != nil {
 		return nil, err
 	}
@@ -34,53 +40,58 @@ func NewDichotomyMethodCalculator(funcStr string, a, b, epsilon float64) (*Dicho
 	}, nil
 }
 
-// eval вычисляет значение функции в точке x
-func (c *DichotomyMethodCalculator) eval(x float64) float64 {
-	res, _ := c.Func.Evaluate(map[string]interface{}{"x": x, "pi": math.Pi, "e": math.E})
-	val, ok := res.(float64)
-	if !ok {
-		return math.NaN()
-	}
-	return val
-}
+func (c *DichotomyMethodCalculator) Calculate(ctx context.Context) (Result, error) {
+	mt := newMeter(ctx)
+	f := mt.wrap(c.Func)
 
-func (c *DichotomyMethodCalculator) Calculate() ([]DichotomyStep, float64, int, error) {
-	var steps []DichotomyStep
+	var res Result
 	a := c.A
 	b := c.B
 
 	// Вычисляем значение функции на концах отрезка
-	fa := c.eval(a)
-	fb := c.eval(b)
+	fa := f(a)
+	fb := f(b)
 
-	if math.IsNaN(fa) || math.IsInf(fa, 0) {
-		return steps, 0, 0, fmt.Errorf("ошибка вычисления функции в точке a=%v", a)
+	if isBad(fa) {
+		return res, fmt.Errorf("ошибка вычисления функции в точке a=%v", a)
 	}
-	if math.IsNaN(fb) || math.IsInf(fb, 0) {
-		return steps, 0, 0, fmt.Errorf("ошибка вычисления функции в точке b=%v", b)
+	if isBad(fb) {
+		return res, fmt.Errorf("ошибка вычисления функции в точке b=%v", b)
 	}
 
 	// Проверяем, что функция имеет разные знаки на концах отрезка
 	if fa*fb > 0 {
-		return steps, 0, 0, fmt.Errorf("функция имеет одинаковые знаки на концах отрезка")
+		return res, fmt.Errorf("функция имеет одинаковые знаки на концах отрезка")
 	}
 
 	// Цикл для вычисления корня
-	for i := 1; i <= maxIter; i++ {
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
 		// Вычисляем середину отрезка
 		mid := (a + b) / 2.0
-		fmid := c.eval(mid)
+		fmid := f(mid)
 
-		if math.IsNaN(fmid) || math.IsInf(fmid, 0) {
-			return steps, 0, i, fmt.Errorf("ошибка вычисления функции в точке x=%v", mid)
+		if isBad(fmid) {
+			return res, fmt.Errorf("ошибка вычисления функции в точке x=%v", mid)
 		}
 
 		// Записываем шаг для фронтенда
-		steps = append(steps, DichotomyStep{A: a, B: b})
-
-		// Проверка на точность или точное попадание в корень
-		if math.Abs(b-a) < c.Epsilon || fmid == 0 {
-			return steps, mid, i, nil
+		res.Steps = record(mt, res.Steps, Step{XPrev: mid, XNew: mid, Fx: fmid, A: a, B: b, Segment: true})
+
+		// Проверка на точность или точное попадание в корень. Шагом
+		// для критериев запроса считается длина текущего отрезка.
+		width := math.Abs(b - a)
+		reason := c.Stop.done(width < c.Epsilon, StopBracket, width, mid, f)
+		if fmid == 0 {
+			reason = StopExact
+		}
+		if reason != "" {
+			res.Root, res.StopReason = mid, reason
+			return res, nil
 		}
 
 		// Сужение отрезка
@@ -94,5 +105,6 @@ func (c *DichotomyMethodCalculator) Calculate() ([]DichotomyStep, float64, int,
 		}
 	}
 
-	return steps, 0, maxIter, fmt.Errorf("превышено максимальное количество итераций")
+	res.StopReason = StopMaxIter
+	return res, fmt.Errorf("превышено максимальное количество итераций")
 }
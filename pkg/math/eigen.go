@@ -0,0 +1,177 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"slices"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/linalg"
+)
+
+// EigenStep - шаг метода поиска собственных значений. Векторные методы
+// (степенной и обратных итераций) заполняют Vector и Value, матричные
+// (вращений и QR) - Values и OffNorm.
+type EigenStep struct {
+	Vector   []float64 // Нормированное приближение собственного вектора
+	Value    float64   // Приближение собственного значения
+	Residual float64   // ||Av - λv||₂ для векторных методов
+	Shift    float64   // Сдвиг, с которым выполнен шаг
+
+	Values  []float64 // Диагональ текущей матрицы - приближения всех собственных значений
+	OffNorm float64   // Норма внедиагональной (для QR - поддиагональной) части
+	Pivot   [2]int    // Строка и столбец обнуляемого элемента в методе вращений
+}
+
+// EigenResult - найденные собственные значения и сверка с gonum
+type EigenResult struct {
+	Values  []complex128 // Найденные собственные значения
+	Vectors [][]float64  // Vectors[k] - собственный вектор для Values[k], если метод их вычисляет
+
+	// Собственные значения, вычисленные gonum (mat.Eigen), в порядке Values,
+	// и наибольшее расхождение с ними
+	Reference []complex128
+	Deviation float64
+
+	Steps      []EigenStep
+	Iterations int
+	StopReason string
+	Info       map[string]any
+}
+
+// EigenSolver - метод поиска собственных значений матрицы.
+// Прерывается так же, как Solver.
+type EigenSolver interface {
+	Calculate(ctx context.Context) (EigenResult, error)
+}
+
+// EigenMethod - описание метода поиска собственных значений: имя, схема параметров и фабрика
+type EigenMethod struct {
+	Name   string
+	Title  string
+	Params []ParamSpec
+
+	// New создает экземпляр метода по уже проверенным параметрам
+	New func(p Params) (EigenSolver, error)
+}
+
+// Методы поиска собственных значений регистрируются отдельно: имена
+// (например, jacobi) совпадают с именами методов решения СЛАУ
+var eigenMethods = newRegistry[EigenMethod]("метод для собственных значений")
+
+// RegisterEigen добавляет метод поиска собственных значений в реестр
+func RegisterEigen(m EigenMethod) {
+	if m.Name == "" || m.New == nil {
+		panic("math: RegisterEigen вызван с пустым именем или фабрикой")
+	}
+	eigenMethods.add(m.Name, m)
+}
+
+// EigenMethods возвращает все методы поиска собственных значений, отсортированные по имени
+func EigenMethods() []EigenMethod {
+	return eigenMethods.all()
+}
+
+// NewEigenSolver находит метод поиска собственных значений по имени, проверяет параметры и создает решатель
+func NewEigenSolver(name string, p Params) (EigenSolver, error) {
+	m, ok := eigenMethods.lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMethod, name)
+	}
+
+	checked, err := p.validate(m.Params)
+	if err != nil {
+		return nil, err
+	}
+	a := checked.Matrix("matrix")
+	if len(a) > maxLinearSize {
+		return nil, fmt.Errorf("порядок матрицы не должен превышать %d", maxLinearSize)
+	}
+	if err := linalg.ValidateSquare(a); err != nil {
+		return nil, err
+	}
+
+	solver, err := m.New(checked)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := solver.(stoppable); ok {
+		stop, err := stopCriteria(checked)
+		if err != nil {
+			return nil, err
+		}
+		s.setStop(stop)
+	}
+	return solver, nil
+}
+
+// Параметры методов поиска собственных значений
+var (
+	eigenMatrixParam  = ParamSpec{Name: "matrix", Type: ParamMatrix, Required: true, Description: "Квадратная матрица по строкам"}
+	eigenX0Param      = ParamSpec{Name: "x0", Type: ParamVector, Description: "Начальное приближение собственного вектора; по умолчанию - вектор из единиц"}
+	eigenParams       = slices.Concat([]ParamSpec{eigenMatrixParam, epsilonParam}, stopParams)
+	eigenVectorParams = slices.Concat([]ParamSpec{eigenMatrixParam, epsilonParam, eigenX0Param}, stopParams)
+)
+
+// eigenX0 возвращает нормированное начальное приближение собственного вектора
+func eigenX0(x0 []float64, n int) ([]float64, error) {
+	if x0 == nil {
+		x0 = ones(n)
+	}
+	if len(x0) != n {
+		return nil, fmt.Errorf("длина начального приближения (%d) не совпадает с порядком матрицы (%d)", len(x0), n)
+	}
+	x := slices.Clone(x0)
+	if !normalize(x) {
+		return nil, fmt.Errorf("начальное приближение не должно быть нулевым вектором")
+	}
+	return x, nil
+}
+
+// crossValidate сопоставляет найденным значениям ближайшие собственные
+// значения, вычисленные gonum, и записывает наибольшее расхождение
+func (res *EigenResult) crossValidate(a [][]float64) error {
+	reference, err := linalg.Eigenvalues(a)
+	if err != nil {
+		return err
+	}
+
+	res.Reference = make([]complex128, len(res.Values))
+	res.Deviation = 0
+	used := make([]bool, len(reference))
+	for i, v := range res.Values {
+		best := -1
+		for j, r := range reference {
+			if !used[j] && (best < 0 || cmplx.Abs(v-r) < cmplx.Abs(v-reference[best])) {
+				best = j
+			}
+		}
+		used[best] = true
+		res.Reference[i] = reference[best]
+		res.Deviation = math.Max(res.Deviation, cmplx.Abs(v-reference[best]))
+	}
+	return nil
+}
+
+// offDiagonalNorm возвращает норму Фробениуса внедиагональной части матрицы
+func offDiagonalNorm(a [][]float64) float64 {
+	s := 0.0
+	for i, row := range a {
+		for j, v := range row {
+			if i != j {
+				s += v * v
+			}
+		}
+	}
+	return math.Sqrt(s)
+}
+
+// diagonal возвращает диагональ матрицы
+func diagonal(a [][]float64) []float64 {
+	d := make([]float64, len(a))
+	for i := range a {
+		d[i] = a[i][i]
+	}
+	return d
+}
@@ -0,0 +1,145 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/linalg"
+	"gonum.org/v1/gonum/floats"
+)
+
+func init() {
+	RegisterEigen(EigenMethod{
+		Name:   "jacobi",
+		Title:  "Метод вращений Якоби (симметричные матрицы)",
+		Params: eigenParams,
+		New: func(p Params) (EigenSolver, error) {
+			return NewJacobiRotationCalculator(p.Matrix("matrix"), p.Float("epsilon"))
+		},
+	})
+}
+
+// JacobiRotationCalculator реализует метод вращений Якоби для симметричных
+// матриц. Каждое вращение A' = JᵀAJ обнуляет наибольший по модулю
+// внедиагональный элемент a_pq, и сумма квадратов внедиагональных элементов
+// уменьшается ровно на 2a_pq². Диагональ сходится к собственным значениям,
+// а произведение вращений - к матрице собственных векторов.
+type JacobiRotationCalculator struct {
+	stopper
+
+	A       [][]float64
+	Epsilon float64
+}
+
+func NewJacobiRotationCalculator(a [][]float64, epsilon float64) (*JacobiRotationCalculator, error) {
+	if err := linalg.CheckSymmetric(a); err != nil {
+		return nil, fmt.Errorf("метод вращений применим только к симметричным матрицам: %w", err)
+	}
+
+	return &JacobiRotationCalculator{A: a, Epsilon: epsilon}, nil
+}
+
+func (c *JacobiRotationCalculator) Calculate(ctx context.Context) (EigenResult, error) {
+	mt := newMeter(ctx)
+	res := EigenResult{Info: map[string]any{"size": len(c.A)}}
+
+	n := len(c.A)
+	a := linalg.Clone(c.A)
+	v := make([][]float64, n)
+	for i := range v {
+		v[i] = make([]float64, n)
+		v[i][i] = 1
+	}
+
+	off := offDiagonalNorm(a)
+	for i := 1; i <= c.Stop.maxIter() && off > 0; i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		p, q := maxOffDiagonal(a)
+		prev := diagonal(a)
+		rotate(a, v, p, q)
+		off = offDiagonalNorm(a)
+
+		values := diagonal(a)
+		res.Steps = record(mt, res.Steps, EigenStep{Values: values, OffNorm: off, Pivot: [2]int{p, q}})
+
+		step := floats.Distance(values, prev, math.Inf(1))
+		if reason := c.Stop.done(off < c.Epsilon, StopOffDiag, step, floats.Norm(values, math.Inf(1)), func(float64) float64 { return off }); reason != "" {
+			res.StopReason = reason
+			break
+		}
+	}
+	switch {
+	case res.StopReason != "":
+	case off == 0:
+		// Матрица диагональная (в том числе порядка 1)
+		res.StopReason = StopExact
+	default:
+		res.StopReason = StopMaxIter
+	}
+
+	// Собственные векторы - столбцы накопленной матрицы вращений
+	res.Values = make([]complex128, n)
+	res.Vectors = make([][]float64, n)
+	for k := 0; k < n; k++ {
+		res.Values[k] = complex(a[k][k], 0)
+		res.Vectors[k] = make([]float64, n)
+		for i := 0; i < n; i++ {
+			res.Vectors[k][i] = v[i][k]
+		}
+	}
+	if err := res.crossValidate(c.A); err != nil {
+		return res, err
+	}
+	if res.StopReason == StopMaxIter {
+		return res, fmt.Errorf("превышено максимальное количество итераций")
+	}
+	return res, nil
+}
+
+// maxOffDiagonal возвращает индексы p < q наибольшего по модулю
+// внедиагонального элемента симметричной матрицы
+func maxOffDiagonal(a [][]float64) (int, int) {
+	p, q := 0, 1
+	for i := range a {
+		for j := i + 1; j < len(a); j++ {
+			if math.Abs(a[i][j]) > math.Abs(a[p][q]) {
+				p, q = i, j
+			}
+		}
+	}
+	return p, q
+}
+
+// rotate выполняет вращение A' = JᵀAJ, обнуляющее a_pq, и накапливает V' = VJ.
+// Угол выбирается меньшим по модулю из двух возможных (|θ| <= π/4), что
+// устойчивее к ошибкам округления.
+func rotate(a, v [][]float64, p, q int) {
+	theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+	t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+	if theta < 0 {
+		t = -t
+	}
+	cos := 1 / math.Sqrt(t*t+1)
+	sin := t * cos
+
+	for k := range a {
+		akp, akq := a[k][p], a[k][q]
+		a[k][p], a[k][q] = cos*akp-sin*akq, sin*akp+cos*akq
+	}
+	for k := range a {
+		apk, aqk := a[p][k], a[q][k]
+		a[p][k], a[q][k] = cos*apk-sin*aqk, sin*apk+cos*aqk
+	}
+	// Убираем ошибку округления в обнуляемых элементах
+	a[p][q], a[q][p] = 0, 0
+
+	for k := range v {
+		vkp, vkq := v[k][p], v[k][q]
+		v[k][p], v[k][q] = cos*vkp-sin*vkq, sin*vkp+cos*vkq
+	}
+}
@@ -0,0 +1,246 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/linalg"
+	"gonum.org/v1/gonum/floats"
+)
+
+func init() {
+	RegisterEigen(EigenMethod{
+		Name:   "power",
+		Title:  "Степенной метод (наибольшее по модулю собственное значение)",
+		Params: eigenVectorParams,
+		New: func(p Params) (EigenSolver, error) {
+			return NewPowerCalculator(p.Matrix("matrix"), p.Vector("x0"), p.Float("epsilon"))
+		},
+	})
+	RegisterEigen(EigenMethod{
+		Name:   "inverse_power",
+		Title:  "Метод обратных итераций со сдвигом",
+		Params: append(slices.Clip(eigenVectorParams), shiftParam, shiftModeParam),
+		New: func(p Params) (EigenSolver, error) {
+			return NewInversePowerCalculator(p.Matrix("matrix"), p.Vector("x0"), p.Float("epsilon"), p.Float("shift"), p.String("shift_mode"))
+		},
+	})
+}
+
+// Способы выбора сдвига в методе обратных итераций
+const (
+	ShiftFixed    = "fixed"    // Сдвиг σ задан и не меняется: сходимость линейная
+	ShiftRayleigh = "rayleigh" // σ - отношение Рэлея текущего приближения: сходимость кубическая для симметричных матриц
+)
+
+var (
+	shiftParam     = ParamSpec{Name: "shift", Type: ParamNumber, Default: 0.0, Description: "Сдвиг σ: метод находит собственное значение, ближайшее к σ"}
+	shiftModeParam = ParamSpec{Name: "shift_mode", Type: ParamString, Default: ShiftFixed, Description: "fixed - постоянный сдвиг, rayleigh - сдвиг по отношению Рэлея на каждой итерации"}
+)
+
+// PowerCalculator реализует степенной метод: x_n+1 = Ax_n / ||Ax_n||.
+// Приближения сходятся к собственному вектору наибольшего по модулю
+// собственного значения со скоростью |λ₂/λ₁|ⁿ; собственное значение
+// оценивается отношением Рэлея λ = (x, Ax). Итерации останавливаются, когда
+// перестает меняться нормированный вектор.
+type PowerCalculator struct {
+	stopper
+
+	A       [][]float64
+	X0      []float64
+	Epsilon float64
+}
+
+func NewPowerCalculator(a [][]float64, x0 []float64, epsilon float64) (*PowerCalculator, error) {
+	x, err := eigenX0(x0, len(a))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PowerCalculator{A: a, X0: x, Epsilon: epsilon}, nil
+}
+
+func (c *PowerCalculator) Calculate(ctx context.Context) (EigenResult, error) {
+	mt := newMeter(ctx)
+	res := EigenResult{Info: map[string]any{"size": len(c.A)}}
+
+	// Скорость сходимости по эталонным собственным значениям
+	if reference, err := linalg.Eigenvalues(c.A); err == nil && len(reference) > 1 {
+		moduli := make([]float64, len(reference))
+		for i, v := range reference {
+			moduli[i] = math.Hypot(real(v), imag(v))
+		}
+		slices.Sort(moduli)
+		if top := moduli[len(moduli)-1]; top > 0 {
+			res.Info["convergence_ratio"] = moduli[len(moduli)-2] / top
+		}
+	}
+
+	x := slices.Clone(c.X0)
+	var lambda float64
+
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		y := linalg.MulVec(c.A, x)
+		lambda = floats.Dot(x, y)
+		residual := eigenResidual(y, x, lambda)
+		res.Steps = record(mt, res.Steps, EigenStep{Vector: slices.Clone(x), Value: lambda, Residual: residual})
+
+		if !normalize(y) {
+			// Ax = 0: x - собственный вектор для λ = 0
+			res.StopReason = StopExact
+			break
+		}
+		// Останавливаемся по сходимости вектора, а не λ: отношение Рэлея
+		// может не меняться и без сходимости (например, для комплексной пары)
+		step := floats.Distance(y, x, math.Inf(1))
+		if reason := c.Stop.done(step < c.Epsilon, StopStep, step, lambda, func(float64) float64 { return residual }); reason != "" {
+			res.StopReason = reason
+			break
+		}
+		x = y
+	}
+
+	res.Values, res.Vectors = []complex128{complex(lambda, 0)}, [][]float64{x}
+	if err := res.crossValidate(c.A); err != nil {
+		return res, err
+	}
+	if res.StopReason == "" {
+		res.StopReason = StopMaxIter
+		return res, fmt.Errorf("превышено максимальное количество итераций: возможно, наибольших по модулю собственных значений несколько (например, комплексная пара)")
+	}
+	return res, nil
+}
+
+// InversePowerCalculator реализует метод обратных итераций со сдвигом:
+// (A - σI)y = x_n, x_n+1 = y / ||y||. Это степенной метод для (A - σI)⁻¹,
+// поэтому он сходится к собственному значению, ближайшему к σ. При сдвиге
+// по отношению Рэлея матрица A - σI на каждой итерации разлагается заново.
+type InversePowerCalculator struct {
+	stopper
+
+	A         [][]float64
+	X0        []float64
+	Epsilon   float64
+	Shift     float64
+	ShiftMode string
+}
+
+func NewInversePowerCalculator(a [][]float64, x0 []float64, epsilon, shift float64, mode string) (*InversePowerCalculator, error) {
+	if mode != ShiftFixed && mode != ShiftRayleigh {
+		return nil, fmt.Errorf("неизвестный способ выбора сдвига %q, ожидается %q или %q", mode, ShiftFixed, ShiftRayleigh)
+	}
+	x, err := eigenX0(x0, len(a))
+	if err != nil {
+		return nil, err
+	}
+
+	return &InversePowerCalculator{A: a, X0: x, Epsilon: epsilon, Shift: shift, ShiftMode: mode}, nil
+}
+
+func (c *InversePowerCalculator) Calculate(ctx context.Context) (EigenResult, error) {
+	mt := newMeter(ctx)
+	res := EigenResult{Info: map[string]any{"size": len(c.A), "shift_mode": c.ShiftMode}}
+
+	n := len(c.A)
+	x := slices.Clone(c.X0)
+	sigma := c.Shift
+	var lambda float64
+	shifted := linalg.Clone(c.A)
+
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		for k := 0; k < n; k++ {
+			shifted[k][k] = c.A[k][k] - sigma
+		}
+		y, err := linalg.Solve(shifted, x)
+		if err != nil || isBadVector(y) || !normalize(y) {
+			// A - σI вырождена: сдвиг совпал с собственным значением, а
+			// собственный вектор - вектор ядра A - σI, а не текущее приближение
+			v, err := linalg.NullVector(shifted)
+			if err != nil {
+				return res, err
+			}
+			normalize(v)
+			residual := eigenResidual(linalg.MulVec(c.A, v), v, sigma)
+			if residual >= c.Epsilon*math.Max(1, math.Abs(sigma)) {
+				return res, fmt.Errorf("матрица A - σI вырождена при σ = %v, но собственный вектор не найден с точностью ε (невязка %v): измените сдвиг", sigma, residual)
+			}
+			x, lambda = v, sigma
+			res.Steps = record(mt, res.Steps, EigenStep{Vector: slices.Clone(x), Value: lambda, Residual: residual, Shift: sigma})
+			res.StopReason = StopExact
+			break
+		}
+		step := floats.Distance(y, x, math.Inf(1))
+		x = y
+
+		ax := linalg.MulVec(c.A, x)
+		lambda = floats.Dot(x, ax)
+		residual := eigenResidual(ax, x, lambda)
+		res.Steps = record(mt, res.Steps, EigenStep{Vector: slices.Clone(x), Value: lambda, Residual: residual, Shift: sigma})
+
+		if reason := c.Stop.done(step < c.Epsilon, StopStep, step, lambda, func(float64) float64 { return residual }); reason != "" {
+			res.StopReason = reason
+			break
+		}
+
+		if c.ShiftMode == ShiftRayleigh {
+			sigma = lambda
+		}
+	}
+
+	res.Values, res.Vectors = []complex128{complex(lambda, 0)}, [][]float64{x}
+	if err := res.crossValidate(c.A); err != nil {
+		return res, err
+	}
+	if res.StopReason == "" {
+		res.StopReason = StopMaxIter
+		return res, fmt.Errorf("превышено максимальное количество итераций: возможно, ближайшие к сдвигу собственные значения комплексные или равноудалены от него")
+	}
+	return res, nil
+}
+
+// normalize делит x на его 2-норму и выбирает знак так, чтобы наибольшая по
+// модулю компонента была положительной: тогда приближения не меняют знак от
+// итерации к итерации при отрицательном собственном значении.
+// Возвращает false для нулевого вектора.
+func normalize(x []float64) bool {
+	norm := floats.Norm(x, 2)
+	if norm == 0 || isBad(norm) {
+		return false
+	}
+	if x[floats.MaxIdx(absVector(x))] < 0 {
+		norm = -norm
+	}
+	floats.Scale(1/norm, x)
+	return true
+}
+
+// absVector возвращает вектор модулей компонент
+func absVector(x []float64) []float64 {
+	abs := make([]float64, len(x))
+	for i, v := range x {
+		abs[i] = math.Abs(v)
+	}
+	return abs
+}
+
+// eigenResidual возвращает ||Ax - λx||₂ по уже вычисленному ax = Ax
+func eigenResidual(ax, x []float64, lambda float64) float64 {
+	s := 0.0
+	for i := range x {
+		d := ax[i] - lambda*x[i]
+		s += d * d
+	}
+	return math.Sqrt(s)
+}
@@ -0,0 +1,174 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/linalg"
+	"gonum.org/v1/gonum/floats"
+)
+
+func init() {
+	RegisterEigen(EigenMethod{
+		Name:   "qr",
+		Title:  "QR-алгоритм без сдвигов",
+		Params: eigenParams,
+		New: func(p Params) (EigenSolver, error) {
+			return NewQRCalculator(p.Matrix("matrix"), p.Float("epsilon"), false), nil
+		},
+	})
+	RegisterEigen(EigenMethod{
+		Name:   "qr_shifted",
+		Title:  "QR-алгоритм со сдвигами Уилкинсона",
+		Params: eigenParams,
+		New: func(p Params) (EigenSolver, error) {
+			return NewQRCalculator(p.Matrix("matrix"), p.Float("epsilon"), true), nil
+		},
+	})
+}
+
+// QRCalculator реализует QR-алгоритм: A_k - μI = Q_kR_k, A_k+1 = R_kQ_k + μI.
+// Все A_k подобны A, а их поддиагональная часть стремится к нулю, так что на
+// диагонали остаются собственные значения. Паре комплексно-сопряженных
+// собственных значений соответствует диагональный блок 2x2, который не
+// исчезает. Без сдвигов (μ = 0) элемент под диагональю убывает как
+// |λ_i+1/λ_i|ⁿ; сдвиг Уилкинсона - собственное значение нижнего блока 2x2
+// еще не отделившейся части матрицы - дает квадратичную сходимость.
+type QRCalculator struct {
+	stopper
+
+	A       [][]float64
+	Epsilon float64
+	Shifted bool
+}
+
+func NewQRCalculator(a [][]float64, epsilon float64, shifted bool) *QRCalculator {
+	return &QRCalculator{A: a, Epsilon: epsilon, Shifted: shifted}
+}
+
+func (c *QRCalculator) Calculate(ctx context.Context) (EigenResult, error) {
+	mt := newMeter(ctx)
+	res := EigenResult{Info: map[string]any{"size": len(c.A)}}
+
+	a := linalg.Clone(c.A)
+	off, values := quasiTriangular(a, c.Epsilon)
+
+	for i := 1; i <= c.Stop.maxIter() && off > 0; i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		shift := 0.0
+		if c.Shifted {
+			shift = wilkinsonShift(a, c.Epsilon)
+		}
+		prev := diagonal(a)
+		a = linalg.QRStep(a, shift)
+		off, values = quasiTriangular(a, c.Epsilon)
+
+		diag := diagonal(a)
+		res.Steps = record(mt, res.Steps, EigenStep{Values: diag, OffNorm: off, Shift: shift})
+
+		step := floats.Distance(diag, prev, math.Inf(1))
+		if reason := c.Stop.done(off < c.Epsilon, StopOffDiag, step, floats.Norm(diag, math.Inf(1)), func(float64) float64 { return off }); reason != "" {
+			res.StopReason = reason
+			break
+		}
+	}
+	switch {
+	case res.StopReason != "":
+	case off == 0:
+		// Матрица уже треугольная
+		res.StopReason = StopExact
+	default:
+		res.StopReason = StopMaxIter
+	}
+
+	res.Values = values
+	if err := res.crossValidate(c.A); err != nil {
+		return res, err
+	}
+	if res.StopReason == StopMaxIter {
+		if !c.Shifted {
+			return res, fmt.Errorf("превышено максимальное количество итераций: без сдвигов QR-алгоритм медленно сходится при близких по модулю собственных значениях")
+		}
+		return res, fmt.Errorf("превышено максимальное количество итераций")
+	}
+	return res, nil
+}
+
+// quasiTriangular разбирает A на диагональные блоки 1x1 и 2x2 и возвращает
+// норму части под ними (которая должна стать нулевой) и собственные значения
+// блоков. Блок 2x2 выделяется, только если его собственные значения
+// комплексные, а сам он отделен от соседних блоков элементами меньше eps.
+func quasiTriangular(a [][]float64, eps float64) (float64, []complex128) {
+	n := len(a)
+	s := 0.0
+	for i := 2; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			s += a[i][j] * a[i][j]
+		}
+	}
+
+	values := make([]complex128, 0, n)
+	for i := 0; i < n; {
+		if i+1 < n {
+			separated := (i == 0 || math.Abs(a[i][i-1]) < eps) && (i+2 == n || math.Abs(a[i+2][i+1]) < eps)
+			if z1, z2, complexPair := block2x2(a, i); complexPair && separated {
+				values = append(values, z1, z2)
+				i += 2
+				continue
+			}
+			s += a[i+1][i] * a[i+1][i]
+		}
+		values = append(values, complex(a[i][i], 0))
+		i++
+	}
+	return math.Sqrt(s), values
+}
+
+// block2x2 возвращает собственные значения блока 2x2 с левым верхним углом
+// a[i][i] и сообщает, комплексные ли они
+func block2x2(a [][]float64, i int) (complex128, complex128, bool) {
+	p, q, r, t := a[i][i], a[i][i+1], a[i+1][i], a[i+1][i+1]
+	half := (p + t) / 2
+	disc := (p-t)*(p-t)/4 + q*r
+	if disc >= 0 {
+		d := math.Sqrt(disc)
+		return complex(half+d, 0), complex(half-d, 0), false
+	}
+	d := math.Sqrt(-disc)
+	return complex(half, d), complex(half, -d), true
+}
+
+// wilkinsonShift выбирает сдвиг по нижнему блоку 2x2 еще не отделившейся
+// части матрицы: собственное значение блока, ближайшее к его правому нижнему
+// элементу. Для комплексной пары берется ее вещественная часть.
+func wilkinsonShift(a [][]float64, eps float64) float64 {
+	// Отбрасываем снизу уже отделившиеся блоки 1x1 и комплексные блоки 2x2
+	m := len(a)
+	for m > 1 {
+		if floats.Norm(a[m-1][:m-1], 2) < eps {
+			m--
+			continue
+		}
+		if _, _, complexPair := block2x2(a, m-2); complexPair && (m == 2 || floats.Norm(a[m-2][:m-2], 2)+floats.Norm(a[m-1][:m-2], 2) < eps) {
+			m -= 2
+			continue
+		}
+		break
+	}
+	if m < 2 {
+		return 0
+	}
+
+	z1, z2, _ := block2x2(a, m-2)
+	corner := complex(a[m-1][m-1], 0)
+	if cmplx.Abs(z1-corner) <= cmplx.Abs(z2-corner) {
+		return real(z1)
+	}
+	return real(z2)
+}
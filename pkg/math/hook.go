@@ -0,0 +1,31 @@
+package math
+
+import "context"
+
+// StepFunc получает каждый шаг сразу после того, как он вычислен: Step,
+// SystemStep, ComplexStep, linalg.Step, LinearIteration, EigenStep или ODEStep,
+// а при прогоне по параметру и поиске всех корней - SweepPoint и IsolatedRoot. Вызывается в той же
+// горутине, что и Calculate, поэтому долгий обработчик замедляет сам расчет.
+type StepFunc func(step any)
+
+type stepHookKey struct{}
+
+// WithStepHook передает шаги всех расчетов, запущенных с этим контекстом, в fn.
+// fn == nil отключает обработчик, заданный выше по цепочке контекстов.
+func WithStepHook(ctx context.Context, fn StepFunc) context.Context {
+	return context.WithValue(ctx, stepHookKey{}, fn)
+}
+
+// stepHook возвращает обработчик шагов контекста или nil
+func stepHook(ctx context.Context) StepFunc {
+	fn, _ := ctx.Value(stepHookKey{}).(StepFunc)
+	return fn
+}
+
+// record добавляет шаг к steps и передает его обработчику шагов расчета
+func record[S any](m *meter, steps []S, step S) []S {
+	if m.onStep != nil {
+		m.onStep(step)
+	}
+	return append(steps, step)
+}
@@ -0,0 +1,115 @@
+package linalg
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// machineEps - расстояние от 1 до следующего числа float64
+const machineEps = 0x1p-52
+
+// Analysis - характеристики квадратной матрицы, от которых зависит точность
+// решения системы Ax = b
+type Analysis struct {
+	Det      float64
+	Inverse  [][]float64 // nil, если матрица вырождена
+	Rank     int         // Число сингулярных чисел больше порога RankTol
+	RankTol  float64
+	Singular []float64 // Сингулярные числа по убыванию
+
+	// Числа обусловленности cond(A) = ||A||·||A⁻¹|| в нормах 1, 2 и ∞;
+	// +Inf для вырожденной матрицы
+	Cond1, Cond2, CondInf float64
+
+	// Левые сингулярные векторы для наибольшего и наименьшего сингулярного
+	// числа: возмущение b вдоль UMin сильнее всего меняет решение
+	UMax, UMin []float64
+}
+
+// Analyze вычисляет определитель, обратную матрицу, ранг и числа
+// обусловленности. Число обусловленности во 2-норме равно σ_max/σ_min и
+// берется из сингулярного разложения, в нормах 1 и ∞ - по обратной матрице.
+func Analyze(a [][]float64) (Analysis, error) {
+	var res Analysis
+	m := fromRows(a)
+	n := len(a)
+
+	var svd mat.SVD
+	if !svd.Factorize(m, mat.SVDFull) {
+		return res, fmt.Errorf("не удалось вычислить сингулярное разложение матрицы")
+	}
+	res.Singular = svd.Values(nil)
+	var u mat.Dense
+	svd.UTo(&u)
+	res.UMax = mat.Col(nil, 0, &u)
+	res.UMin = mat.Col(nil, n-1, &u)
+
+	// Порог как в numpy.linalg.matrix_rank: σ_max·n·ε
+	sigmaMax := res.Singular[0]
+	res.RankTol = sigmaMax * float64(n) * machineEps
+	for _, s := range res.Singular {
+		if s > res.RankTol {
+			res.Rank++
+		}
+	}
+
+	res.Det = mat.Det(m)
+	res.Cond1, res.Cond2, res.CondInf = math.Inf(1), math.Inf(1), math.Inf(1)
+	if res.Rank < n {
+		return res, nil
+	}
+
+	var inv mat.Dense
+	if err := inv.Inverse(m); err != nil && !illConditioned(err) {
+		return res, nil
+	}
+	res.Inverse = toRows(&inv)
+	res.Cond1 = mat.Norm(m, 1) * mat.Norm(&inv, 1)
+	res.Cond2 = sigmaMax / res.Singular[n-1]
+	res.CondInf = mat.Norm(m, math.Inf(1)) * mat.Norm(&inv, math.Inf(1))
+	return res, nil
+}
+
+// Hilbert возвращает матрицу Гильберта h_ij = 1/(i + j + 1) - классический
+// пример плохо обусловленной матрицы: cond₂ растет примерно как e^(3.5n)
+func Hilbert(n int) [][]float64 {
+	h := make([][]float64, n)
+	for i := range h {
+		h[i] = make([]float64, n)
+		for j := range h[i] {
+			h[i][j] = 1 / float64(i+j+1)
+		}
+	}
+	return h
+}
+
+// MulVec возвращает произведение Ax
+func MulVec(a [][]float64, x []float64) []float64 {
+	y := make([]float64, len(a))
+	for i, row := range a {
+		for j, v := range row {
+			y[i] += v * x[j]
+		}
+	}
+	return y
+}
+
+// Solve решает систему Ax = b через LU-разложение gonum без записи шагов
+func Solve(a [][]float64, b []float64) ([]float64, error) {
+	var x mat.VecDense
+	if err := x.SolveVec(fromRows(a), mat.NewVecDense(len(b), append([]float64(nil), b...))); err != nil && !illConditioned(err) {
+		return nil, fmt.Errorf("%w: %v", ErrSingular, err)
+	}
+	return x.RawVector().Data, nil
+}
+
+// illConditioned сообщает, что gonum вычислил результат, но предупреждает о
+// плохой обусловленности. Для анализа такой результат и нужен: он показывает,
+// насколько велика потеря точности.
+func illConditioned(err error) bool {
+	var cond mat.Condition
+	return errors.As(err, &cond)
+}
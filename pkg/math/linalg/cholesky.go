@@ -0,0 +1,65 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+)
+
+// Cholesky решает систему с симметричной положительно определенной матрицей
+// через разложение A = LLᵀ. Разложение требует вдвое меньше операций, чем LU,
+// и не нуждается в выборе ведущего элемента. Если под корнем на диагонали
+// получается неположительное число, матрица не положительно определена.
+func Cholesky(a [][]float64, b []float64) (Solution, error) {
+	if err := Validate(a, b); err != nil {
+		return Solution{}, err
+	}
+
+	if err := CheckSymmetric(a); err != nil {
+		return Solution{}, err
+	}
+
+	n := len(a)
+
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+	}
+
+	var sol Solution
+	det := 1.0
+
+	for j := 0; j < n; j++ {
+		d := a[j][j]
+		for k := 0; k < j; k++ {
+			d -= l[j][k] * l[j][k]
+		}
+		if d <= pivotTol(a) {
+			return sol, fmt.Errorf("%w: на шаге %d под корнем получилось %v", ErrNotPositive, j+1, d)
+		}
+		l[j][j] = math.Sqrt(d)
+		det *= d
+
+		for i := j + 1; i < n; i++ {
+			s := a[i][j]
+			for k := 0; k < j; k++ {
+				s -= l[i][k] * l[j][k]
+			}
+			l[i][j] = s / l[j][j]
+		}
+		sol.Steps = append(sol.Steps, Step{Kind: StepFactor, Row: j, Col: j, Pivot: l[j][j], Matrix: transpose(l), L: Clone(l)})
+	}
+
+	// Ly = b, затем Lᵀx = y
+	y := make([]float64, n)
+	for i := range y {
+		s := b[i]
+		for k := 0; k < i; k++ {
+			s -= l[i][k] * y[k]
+		}
+		y[i] = s / l[i][i]
+	}
+
+	sol.X = backSubstitute(augment(transpose(l), y))
+	sol.Det = det
+	return sol, nil
+}
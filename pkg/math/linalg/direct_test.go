@@ -0,0 +1,168 @@
+package linalg
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+const tol = 1e-9
+
+// system - система с известным решением и определителем
+type system struct {
+	name string
+	a    [][]float64
+	b    []float64
+	x    []float64
+	det  float64
+}
+
+var (
+	// Общая матрица с нулем на диагонали: без выбора ведущего элемента Гаусс не работает
+	general = system{
+		name: "general",
+		a:    [][]float64{{0, 2, 1}, {1, 1, 1}, {2, 1, -1}},
+		b:    []float64{5, 5, 0},
+		x:    []float64{1, 1, 3},
+		det:  5,
+	}
+	// Симметричная положительно определенная матрица
+	spd = system{
+		name: "spd",
+		a:    [][]float64{{4, 12, -16}, {12, 37, -43}, {-16, -43, 98}},
+		b:    []float64{0, 6, 39},
+		x:    []float64{1, 1, 1},
+		det:  36,
+	}
+	// Трехдиагональная матрица с диагональным преобладанием
+	tridiagonal = system{
+		name: "tridiagonal",
+		a:    [][]float64{{2, -1, 0, 0}, {-1, 2, -1, 0}, {0, -1, 2, -1}, {0, 0, -1, 2}},
+		b:    []float64{0, 0, 0, 5},
+		x:    []float64{1, 2, 3, 4},
+		det:  5,
+	}
+)
+
+func checkSolution(t *testing.T, s system, sol Solution, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: неожиданная ошибка: %v", s.name, err)
+	}
+	if len(sol.X) != len(s.x) {
+		t.Fatalf("%s: len(x) = %d, ожидалось %d", s.name, len(sol.X), len(s.x))
+	}
+	for i := range s.x {
+		if math.Abs(sol.X[i]-s.x[i]) > tol {
+			t.Errorf("%s: x[%d] = %v, ожидалось %v", s.name, i, sol.X[i], s.x[i])
+		}
+	}
+	if math.Abs(sol.Det-s.det) > tol*math.Max(1, math.Abs(s.det)) {
+		t.Errorf("%s: det = %v, ожидалось %v", s.name, sol.Det, s.det)
+	}
+	if len(sol.Steps) == 0 {
+		t.Errorf("%s: шаги метода не записаны", s.name)
+	}
+}
+
+func TestGauss(t *testing.T) {
+	for _, s := range []system{general, spd, tridiagonal} {
+		for _, pivoting := range []Pivoting{PivotPartial, PivotComplete} {
+			t.Run(s.name+"/"+string(pivoting), func(t *testing.T) {
+				sol, err := Gauss(s.a, s.b, pivoting)
+				checkSolution(t, s, sol, err)
+			})
+		}
+	}
+}
+
+func TestGaussWithoutPivoting(t *testing.T) {
+	sol, err := Gauss(spd.a, spd.b, PivotNone)
+	checkSolution(t, spd, sol, err)
+
+	// Нулевой диагональный элемент без перестановок - отказ метода
+	if _, err := Gauss(general.a, general.b, PivotNone); !errors.Is(err, ErrSingular) {
+		t.Errorf("ошибка = %v, ожидалась ErrSingular", err)
+	}
+}
+
+func TestGaussSingular(t *testing.T) {
+	a := [][]float64{{1, 2}, {2, 4}}
+	for _, pivoting := range []Pivoting{PivotNone, PivotPartial, PivotComplete} {
+		if _, err := Gauss(a, []float64{1, 2}, pivoting); !errors.Is(err, ErrSingular) {
+			t.Errorf("%s: ошибка = %v, ожидалась ErrSingular", pivoting, err)
+		}
+	}
+}
+
+func TestGaussDoesNotModifyInput(t *testing.T) {
+	a := Clone(general.a)
+	b := append([]float64(nil), general.b...)
+	if _, err := Gauss(a, b, PivotComplete); err != nil {
+		t.Fatal(err)
+	}
+	for i := range a {
+		for j := range a[i] {
+			if a[i][j] != general.a[i][j] {
+				t.Fatalf("матрица изменена: a[%d][%d] = %v", i, j, a[i][j])
+			}
+		}
+		if b[i] != general.b[i] {
+			t.Fatalf("правая часть изменена: b[%d] = %v", i, b[i])
+		}
+	}
+}
+
+func TestLU(t *testing.T) {
+	for _, s := range []system{spd, tridiagonal} {
+		t.Run(s.name, func(t *testing.T) {
+			sol, err := LU(s.a, s.b)
+			checkSolution(t, s, sol, err)
+		})
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	for _, s := range []system{spd, tridiagonal} {
+		t.Run(s.name, func(t *testing.T) {
+			sol, err := Cholesky(s.a, s.b)
+			checkSolution(t, s, sol, err)
+		})
+	}
+
+	if _, err := Cholesky(general.a, general.b); !errors.Is(err, ErrNotSymmetric) {
+		t.Errorf("несимметричная матрица: ошибка = %v, ожидалась ErrNotSymmetric", err)
+	}
+	indefinite := [][]float64{{1, 2}, {2, 1}}
+	if _, err := Cholesky(indefinite, []float64{1, 1}); !errors.Is(err, ErrNotPositive) {
+		t.Errorf("незнакоопределенная матрица: ошибка = %v, ожидалась ErrNotPositive", err)
+	}
+}
+
+func TestThomas(t *testing.T) {
+	sol, err := Thomas(tridiagonal.a, tridiagonal.b)
+	checkSolution(t, tridiagonal, sol, err)
+
+	if _, err := Thomas(general.a, general.b); !errors.Is(err, ErrNotTridiagonal) {
+		t.Errorf("ошибка = %v, ожидалась ErrNotTridiagonal", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		a    [][]float64
+		b    []float64
+	}{
+		{"empty", nil, nil},
+		{"not square", [][]float64{{1, 2}, {3}}, []float64{1, 2}},
+		{"rhs length", [][]float64{{1, 0}, {0, 1}}, []float64{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.a, tt.b); err == nil {
+				t.Error("ожидалась ошибка")
+			}
+		})
+	}
+}
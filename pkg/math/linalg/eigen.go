@@ -0,0 +1,52 @@
+package linalg
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Eigenvalues возвращает собственные значения матрицы, вычисленные LAPACK
+// через gonum. Используется как эталон для проверки итерационных методов.
+func Eigenvalues(a [][]float64) ([]complex128, error) {
+	var eig mat.Eigen
+	if !eig.Factorize(fromRows(a), mat.EigenNone) {
+		return nil, fmt.Errorf("не удалось вычислить собственные значения матрицы")
+	}
+	return eig.Values(nil), nil
+}
+
+// NullVector возвращает единичный вектор v, на котором ||Av||₂ наименьшая, -
+// правый сингулярный вектор для наименьшего сингулярного числа. Для
+// вырожденной матрицы это вектор ее ядра.
+func NullVector(a [][]float64) ([]float64, error) {
+	var svd mat.SVD
+	if !svd.Factorize(fromRows(a), mat.SVDFull) {
+		return nil, fmt.Errorf("не удалось вычислить сингулярное разложение матрицы")
+	}
+	var v mat.Dense
+	svd.VTo(&v)
+	// Сингулярные числа упорядочены по убыванию
+	return mat.Col(nil, len(a)-1, &v), nil
+}
+
+// QRStep выполняет шаг QR-алгоритма со сдвигом μ: A - μI = QR, A' = RQ + μI.
+// A' подобна A, поэтому у нее те же собственные значения.
+func QRStep(a [][]float64, shift float64) [][]float64 {
+	n := len(a)
+	m := fromRows(a)
+	for i := 0; i < n; i++ {
+		m.Set(i, i, m.At(i, i)-shift)
+	}
+
+	var qr mat.QR
+	qr.Factorize(m)
+	var q, r, next mat.Dense
+	qr.QTo(&q)
+	qr.RTo(&r)
+	next.Mul(&r, &q)
+	for i := 0; i < n; i++ {
+		next.Set(i, i, next.At(i, i)+shift)
+	}
+	return toRows(&next)
+}
@@ -0,0 +1,102 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+)
+
+// Pivoting - стратегия выбора ведущего элемента в методе Гаусса
+type Pivoting string
+
+const (
+	PivotNone     Pivoting = "none"     // Ведущий элемент - диагональный (неустойчиво, для сравнения)
+	PivotPartial  Pivoting = "partial"  // Наибольший по модулю в столбце
+	PivotComplete Pivoting = "complete" // Наибольший по модулю в оставшейся подматрице
+)
+
+// Gauss решает систему методом Гаусса. Прямой ход приводит расширенную
+// матрицу [A|b] к верхнему треугольному виду, обратный ход находит x.
+// При полном выборе переставляются и столбцы, поэтому неизвестные
+// возвращаются на свои места по перестановке perm.
+func Gauss(a [][]float64, b []float64, pivoting Pivoting) (Solution, error) {
+	if err := Validate(a, b); err != nil {
+		return Solution{}, err
+	}
+	switch pivoting {
+	case "":
+		pivoting = PivotPartial
+	case PivotNone, PivotPartial, PivotComplete:
+	default:
+		return Solution{}, fmt.Errorf("неизвестная стратегия выбора ведущего элемента %q", pivoting)
+	}
+
+	n := len(a)
+	m := augment(a, b)
+	tol := pivotTol(a)
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+
+	var sol Solution
+	det := 1.0
+
+	for k := 0; k < n; k++ {
+		// Выбираем ведущий элемент
+		pr, pc := k, k
+		switch pivoting {
+		case PivotPartial:
+			for i := k + 1; i < n; i++ {
+				if math.Abs(m[i][k]) > math.Abs(m[pr][k]) {
+					pr = i
+				}
+			}
+		case PivotComplete:
+			for i := k; i < n; i++ {
+				for j := k; j < n; j++ {
+					if math.Abs(m[i][j]) > math.Abs(m[pr][pc]) {
+						pr, pc = i, j
+					}
+				}
+			}
+		}
+
+		if pr != k {
+			m[k], m[pr] = m[pr], m[k]
+			det = -det
+			sol.Steps = append(sol.Steps, Step{Kind: StepSwapRows, Row: k, Col: k, Other: pr, Pivot: m[k][k], Matrix: Clone(m)})
+		}
+		if pc != k {
+			for i := range m {
+				m[i][k], m[i][pc] = m[i][pc], m[i][k]
+			}
+			perm[k], perm[pc] = perm[pc], perm[k]
+			det = -det
+			sol.Steps = append(sol.Steps, Step{Kind: StepSwapCols, Row: k, Col: k, Other: pc, Pivot: m[k][k], Matrix: Clone(m)})
+		}
+
+		pivot := m[k][k]
+		if math.Abs(pivot) <= tol {
+			return sol, fmt.Errorf("%w: ведущий элемент на шаге %d равен %v", ErrSingular, k+1, pivot)
+		}
+		det *= pivot
+
+		// Исключаем x_k из строк ниже ведущей
+		for i := k + 1; i < n; i++ {
+			factor := m[i][k] / pivot
+			m[i][k] = 0
+			for j := k + 1; j <= n; j++ {
+				m[i][j] -= factor * m[k][j]
+			}
+		}
+		sol.Steps = append(sol.Steps, Step{Kind: StepEliminate, Row: k, Col: k, Pivot: pivot, Matrix: Clone(m)})
+	}
+
+	y := backSubstitute(m)
+	sol.X = make([]float64, n)
+	for j, p := range perm {
+		sol.X[p] = y[j]
+	}
+	sol.Det = det
+	return sol, nil
+}
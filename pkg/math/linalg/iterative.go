@@ -0,0 +1,157 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Виды диагонального преобладания
+const (
+	DominanceStrict = "strict" // |a_ii| > Σ|a_ij| в каждой строке
+	DominanceWeak   = "weak"   // |a_ii| >= Σ|a_ij| в каждой строке
+	DominanceNone   = "none"
+)
+
+// DiagonalDominance определяет диагональное преобладание по строкам. Строгое
+// преобладание - достаточное условие сходимости методов Якоби и Зейделя.
+func DiagonalDominance(a [][]float64) string {
+	kind := DominanceStrict
+	for i, row := range a {
+		off := 0.0
+		for j, v := range row {
+			if j != i {
+				off += math.Abs(v)
+			}
+		}
+		d := math.Abs(row[i])
+		switch {
+		case d > off:
+		case d == off:
+			kind = DominanceWeak
+		default:
+			return DominanceNone
+		}
+	}
+	return kind
+}
+
+// CheckDiagonal проверяет, что на диагонали нет нулей: методы Якоби и
+// релаксации делят на диагональные элементы
+func CheckDiagonal(a [][]float64) error {
+	for i := range a {
+		if a[i][i] == 0 {
+			return fmt.Errorf("%w: диагональный элемент a[%d][%d] равен нулю, переставьте уравнения", ErrSingular, i+1, i+1)
+		}
+	}
+	return nil
+}
+
+// JacobiMatrix возвращает матрицу итераций метода Якоби B = -D⁻¹(L + U)
+func JacobiMatrix(a [][]float64) [][]float64 {
+	n := len(a)
+	b := make([][]float64, n)
+	for i := range b {
+		b[i] = make([]float64, n)
+		for j := range b[i] {
+			if j != i {
+				b[i][j] = -a[i][j] / a[i][i]
+			}
+		}
+	}
+	return b
+}
+
+// SORMatrix возвращает матрицу итераций метода релаксации
+//
+//	B = (D + ωL)⁻¹((1 - ω)D - ωU),
+//
+// при ω = 1 это матрица метода Зейделя
+func SORMatrix(a [][]float64, omega float64) ([][]float64, error) {
+	n := len(a)
+	left := mat.NewDense(n, n, nil)
+	right := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			switch {
+			case j < i:
+				left.Set(i, j, omega*a[i][j])
+			case j == i:
+				left.Set(i, j, a[i][i])
+				right.Set(i, j, (1-omega)*a[i][i])
+			default:
+				right.Set(i, j, -omega*a[i][j])
+			}
+		}
+	}
+
+	var b mat.Dense
+	if err := b.Solve(left, right); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSingular, err)
+	}
+	return toRows(&b), nil
+}
+
+// SpectralRadius возвращает наибольший модуль собственного значения матрицы.
+// Итерации x_n+1 = Bx_n + c сходятся из любого x0 тогда и только тогда, когда ρ(B) < 1.
+func SpectralRadius(m [][]float64) (float64, error) {
+	values, err := Eigenvalues(m)
+	if err != nil {
+		return 0, err
+	}
+
+	rho := 0.0
+	for _, v := range values {
+		rho = math.Max(rho, cmplx.Abs(v))
+	}
+	return rho, nil
+}
+
+// OptimalOmega - оптимальный параметр релаксации для согласованно
+// упорядоченных матриц (например, трехдиагональных) по спектральному
+// радиусу ρ_J матрицы Якоби: ω = 2 / (1 + √(1 - ρ_J²)). Для ρ_J >= 1
+// формула неприменима, и возвращается ω = 1 (метод Зейделя).
+func OptimalOmega(rhoJacobi float64) float64 {
+	if rhoJacobi >= 1 {
+		return 1
+	}
+	return 2 / (1 + math.Sqrt(1-rhoJacobi*rhoJacobi))
+}
+
+// Residual возвращает невязку r = b - Ax
+func Residual(a [][]float64, b, x []float64) []float64 {
+	r := make([]float64, len(b))
+	for i, row := range a {
+		s := b[i]
+		for j, v := range row {
+			s -= v * x[j]
+		}
+		r[i] = s
+	}
+	return r
+}
+
+// fromRows переводит матрицу по строкам в *mat.Dense
+func fromRows(a [][]float64) *mat.Dense {
+	n, m := len(a), len(a[0])
+	d := mat.NewDense(n, m, nil)
+	for i, row := range a {
+		d.SetRow(i, row)
+	}
+	return d
+}
+
+// toRows переводит mat.Matrix в матрицу по строкам
+func toRows(m mat.Matrix) [][]float64 {
+	r, c := m.Dims()
+	rows := make([][]float64, r)
+	for i := range rows {
+		rows[i] = make([]float64, c)
+		for j := range rows[i] {
+			rows[i][j] = m.At(i, j)
+		}
+	}
+	return rows
+}
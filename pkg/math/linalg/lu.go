@@ -0,0 +1,74 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+)
+
+// LU решает систему через разложение PA = LU с выбором ведущего элемента по
+// столбцу: L - нижняя треугольная с единицами на диагонали, U - верхняя
+// треугольная. Затем решаются две треугольные системы Ly = Pb и Ux = y.
+// Разложение не зависит от b, поэтому его можно использовать повторно.
+func LU(a [][]float64, b []float64) (Solution, error) {
+	if err := Validate(a, b); err != nil {
+		return Solution{}, err
+	}
+
+	n := len(a)
+	u := Clone(a)
+	l := identity(n)
+	rhs := append([]float64(nil), b...)
+	tol := pivotTol(a)
+
+	var sol Solution
+	det := 1.0
+
+	for k := 0; k < n; k++ {
+		pr := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(u[i][k]) > math.Abs(u[pr][k]) {
+				pr = i
+			}
+		}
+		if pr != k {
+			// Переставляем строки U, правой части и уже вычисленные множители L
+			u[k], u[pr] = u[pr], u[k]
+			rhs[k], rhs[pr] = rhs[pr], rhs[k]
+			for j := 0; j < k; j++ {
+				l[k][j], l[pr][j] = l[pr][j], l[k][j]
+			}
+			det = -det
+			sol.Steps = append(sol.Steps, Step{Kind: StepSwapRows, Row: k, Col: k, Other: pr, Pivot: u[k][k], Matrix: Clone(u), L: Clone(l)})
+		}
+
+		pivot := u[k][k]
+		if math.Abs(pivot) <= tol {
+			return sol, fmt.Errorf("%w: ведущий элемент на шаге %d равен %v", ErrSingular, k+1, pivot)
+		}
+		det *= pivot
+
+		for i := k + 1; i < n; i++ {
+			factor := u[i][k] / pivot
+			l[i][k] = factor
+			u[i][k] = 0
+			for j := k + 1; j < n; j++ {
+				u[i][j] -= factor * u[k][j]
+			}
+		}
+		sol.Steps = append(sol.Steps, Step{Kind: StepFactor, Row: k, Col: k, Pivot: pivot, Matrix: Clone(u), L: Clone(l)})
+	}
+
+	// Прямая подстановка Ly = Pb (диагональ L единичная)
+	y := make([]float64, n)
+	for i := range y {
+		s := rhs[i]
+		for j := 0; j < i; j++ {
+			s -= l[i][j] * y[j]
+		}
+		y[i] = s
+	}
+
+	sol.X = backSubstitute(augment(u, y))
+	sol.Det = det
+	return sol, nil
+}
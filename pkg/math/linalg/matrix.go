@@ -0,0 +1,165 @@
+// Package linalg содержит прямые методы решения систем линейных уравнений
+// Ax = b, а также вспомогательные вычисления для итерационных методов и
+// анализа обусловленности. Каждый прямой метод записывает состояние рабочей
+// матрицы после каждого шага исключения, чтобы процесс можно было показать по шагам.
+package linalg
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrSingular       = errors.New("матрица вырождена или близка к вырожденной")
+	ErrNotSymmetric   = errors.New("матрица не симметрична")
+	ErrNotPositive    = errors.New("матрица не является положительно определенной")
+	ErrNotTridiagonal = errors.New("матрица не трехдиагональная")
+)
+
+// Виды шагов
+const (
+	StepSwapRows  = "swap_rows" // Перестановка строк Row и Other
+	StepSwapCols  = "swap_cols" // Перестановка столбцов Col и Other
+	StepEliminate = "eliminate" // Исключение неизвестной Col под ведущим элементом
+	StepFactor    = "factor"    // Вычислен столбец множителя разложения (LU, Холецкий)
+)
+
+// Step - состояние метода после одного шага
+type Step struct {
+	Kind  string
+	Row   int     // Строка ведущего элемента (с нуля)
+	Col   int     // Столбец ведущего элемента (с нуля)
+	Other int     // Строка или столбец, с которыми выполнена перестановка
+	Pivot float64 // Ведущий элемент шага
+
+	// Рабочая матрица после шага: расширенная [A|b] для метода Гаусса и
+	// прогонки, U для LU-разложения, Lᵀ для метода Холецкого
+	Matrix [][]float64
+
+	// Нижний треугольный множитель разложения (LU, Холецкий)
+	L [][]float64
+}
+
+// Solution - решение системы вместе с шагами метода
+type Solution struct {
+	X     []float64
+	Det   float64
+	Steps []Step
+}
+
+// Validate проверяет, что A квадратная, а длина b совпадает с ее порядком
+func Validate(a [][]float64, b []float64) error {
+	if err := ValidateSquare(a); err != nil {
+		return err
+	}
+	if len(b) != len(a) {
+		return fmt.Errorf("длина правой части (%d) не совпадает с порядком матрицы (%d)", len(b), len(a))
+	}
+	return nil
+}
+
+// ValidateSquare проверяет, что матрица непустая, квадратная и из конечных чисел
+func ValidateSquare(a [][]float64) error {
+	n := len(a)
+	if n == 0 {
+		return fmt.Errorf("матрица пуста")
+	}
+	for i, row := range a {
+		if len(row) != n {
+			return fmt.Errorf("матрица должна быть квадратной: в строке %d %d элементов вместо %d", i+1, len(row), n)
+		}
+		for j, v := range row {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return fmt.Errorf("элемент (%d, %d) не является конечным числом", i+1, j+1)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckSymmetric проверяет симметричность матрицы с точностью до ошибок округления
+func CheckSymmetric(a [][]float64) error {
+	symTol := 1e-12 * maxAbs(a)
+	for i := range a {
+		for j := i + 1; j < len(a); j++ {
+			if math.Abs(a[i][j]-a[j][i]) > symTol {
+				return fmt.Errorf("%w: a[%d][%d] = %v, a[%d][%d] = %v", ErrNotSymmetric, i+1, j+1, a[i][j], j+1, i+1, a[j][i])
+			}
+		}
+	}
+	return nil
+}
+
+// Clone возвращает копию матрицы
+func Clone(a [][]float64) [][]float64 {
+	c := make([][]float64, len(a))
+	for i, row := range a {
+		c[i] = append([]float64(nil), row...)
+	}
+	return c
+}
+
+// augment возвращает расширенную матрицу [A|b]
+func augment(a [][]float64, b []float64) [][]float64 {
+	m := make([][]float64, len(a))
+	for i, row := range a {
+		m[i] = make([]float64, len(row)+1)
+		copy(m[i], row)
+		m[i][len(row)] = b[i]
+	}
+	return m
+}
+
+// identity возвращает единичную матрицу порядка n
+func identity(n int) [][]float64 {
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+		m[i][i] = 1
+	}
+	return m
+}
+
+// transpose возвращает транспонированную матрицу
+func transpose(a [][]float64) [][]float64 {
+	t := make([][]float64, len(a[0]))
+	for j := range t {
+		t[j] = make([]float64, len(a))
+		for i := range a {
+			t[j][i] = a[i][j]
+		}
+	}
+	return t
+}
+
+// maxAbs возвращает наибольший модуль элемента матрицы
+func maxAbs(a [][]float64) float64 {
+	m := 0.0
+	for _, row := range a {
+		for _, v := range row {
+			m = math.Max(m, math.Abs(v))
+		}
+	}
+	return m
+}
+
+// pivotTol - порог, ниже которого ведущий элемент считается нулевым
+func pivotTol(a [][]float64) float64 {
+	return float64(len(a)) * 1e-14 * maxAbs(a)
+}
+
+// backSubstitute решает верхнюю треугольную систему, записанную в
+// расширенной матрице m = [U|y]
+func backSubstitute(m [][]float64) []float64 {
+	n := len(m)
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		s := m[i][n]
+		for j := i + 1; j < n; j++ {
+			s -= m[i][j] * x[j]
+		}
+		x[i] = s / m[i][i]
+	}
+	return x
+}
@@ -0,0 +1,59 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+)
+
+// Thomas решает трехдиагональную систему методом прогонки. Прямой ход
+// исключает поддиагональ, нормируя каждую строку на ее диагональный элемент,
+// обратный ход находит x_i = d'_i - c'_i x_i+1. Требуется O(n) операций.
+// Прогонка устойчива при диагональном преобладании; ведущие элементы не
+// выбираются, поэтому нулевой знаменатель означает отказ метода.
+func Thomas(a [][]float64, b []float64) (Solution, error) {
+	if err := Validate(a, b); err != nil {
+		return Solution{}, err
+	}
+
+	n := len(a)
+	for i := range a {
+		for j := range a[i] {
+			if (j < i-1 || j > i+1) && a[i][j] != 0 {
+				return Solution{}, fmt.Errorf("%w: ненулевой элемент (%d, %d)", ErrNotTridiagonal, i+1, j+1)
+			}
+		}
+	}
+
+	m := augment(a, b)
+	tol := pivotTol(a)
+
+	var sol Solution
+	det := 1.0
+
+	for i := 0; i < n; i++ {
+		// Исключаем поддиагональный элемент строки i с помощью уже нормированной строки i-1
+		if i > 0 {
+			factor := m[i][i-1]
+			m[i][i-1] = 0
+			m[i][i] -= factor * m[i-1][i]
+			m[i][n] -= factor * m[i-1][n]
+		}
+
+		pivot := m[i][i]
+		if math.Abs(pivot) <= tol {
+			return sol, fmt.Errorf("%w: знаменатель прогонки на шаге %d равен %v", ErrSingular, i+1, pivot)
+		}
+		det *= pivot
+
+		m[i][i] = 1
+		if i+1 < n {
+			m[i][i+1] /= pivot
+		}
+		m[i][n] /= pivot
+		sol.Steps = append(sol.Steps, Step{Kind: StepEliminate, Row: i, Col: i, Pivot: pivot, Matrix: Clone(m)})
+	}
+
+	sol.X = backSubstitute(m)
+	sol.Det = det
+	return sol, nil
+}
@@ -0,0 +1,122 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/linalg"
+	"gonum.org/v1/gonum/floats"
+)
+
+// LinearResult - решение системы линейных уравнений Ax = b
+type LinearResult struct {
+	X        []float64
+	Det      float64 // Определитель (вычисляют только прямые методы)
+	Residual float64 // ||Ax - b||₂
+	Info     map[string]any
+
+	// Шаги прямого метода: состояние матрицы после каждого исключения
+	Steps []linalg.Step
+
+	// Итерации итерационного метода с невязкой на каждой из них
+	Trace      []LinearIteration
+	Iterations int
+	StopReason string
+}
+
+// LinearIteration - одна итерация итерационного метода решения СЛАУ
+type LinearIteration struct {
+	X        []float64 // Приближение x_n+1
+	Residual float64   // ||b - Ax_n+1||₂
+	StepNorm float64   // ||x_n+1 - x_n||∞
+}
+
+// LinearSolver - метод решения системы линейных уравнений (задание 1).
+// Прерывается так же, как Solver.
+type LinearSolver interface {
+	Calculate(ctx context.Context) (LinearResult, error)
+}
+
+// LinearMethod - описание метода решения СЛАУ: имя, схема параметров и фабрика
+type LinearMethod struct {
+	Name   string
+	Title  string
+	Params []ParamSpec
+
+	// New создает экземпляр метода по уже проверенным параметрам
+	New func(p Params) (LinearSolver, error)
+}
+
+// Методы решения СЛАУ регистрируются отдельно от методов поиска корней:
+// у них другие параметры и результат
+var linearMethods = newRegistry[LinearMethod]("метод для СЛАУ")
+
+// RegisterLinear добавляет метод решения СЛАУ в реестр
+func RegisterLinear(m LinearMethod) {
+	if m.Name == "" || m.New == nil {
+		panic("math: RegisterLinear вызван с пустым именем или фабрикой")
+	}
+	linearMethods.add(m.Name, m)
+}
+
+// LinearMethods возвращает все методы решения СЛАУ, отсортированные по имени
+func LinearMethods() []LinearMethod {
+	return linearMethods.all()
+}
+
+// NewLinearSolver находит метод решения СЛАУ по имени, проверяет параметры и создает решатель
+func NewLinearSolver(name string, p Params) (LinearSolver, error) {
+	m, ok := linearMethods.lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMethod, name)
+	}
+
+	checked, err := p.validate(m.Params)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLinearSystem(checked.Matrix("matrix"), checked.Vector("b")); err != nil {
+		return nil, err
+	}
+
+	solver, err := m.New(checked)
+	if err != nil {
+		return nil, err
+	}
+	// Критерии остановки есть только у итерационных методов
+	if s, ok := solver.(stoppable); ok {
+		stop, err := stopCriteria(checked)
+		if err != nil {
+			return nil, err
+		}
+		s.setStop(stop)
+	}
+	return solver, nil
+}
+
+// Общие параметры методов решения СЛАУ
+var (
+	matrixParam   = ParamSpec{Name: "matrix", Type: ParamMatrix, Required: true, Description: "Матрица системы A по строкам, например [[4, 1], [1, 3]]"}
+	rhsParam      = ParamSpec{Name: "b", Type: ParamVector, Required: true, Description: "Правая часть b"}
+	pivotingParam = ParamSpec{Name: "pivoting", Type: ParamString, Default: string(linalg.PivotPartial), Description: "Выбор ведущего элемента: none, partial (по столбцу) или complete (по всей подматрице)"}
+	x0LinearParam = ParamSpec{Name: "x0", Type: ParamVector, Description: "Начальное приближение; по умолчанию - нулевой вектор"}
+	linearParams  = []ParamSpec{matrixParam, rhsParam}
+
+	// Итерационные методы дополнительно принимают точность, начальное
+	// приближение и общие критерии остановки
+	iterativeLinearParams = slices.Concat(linearParams, []ParamSpec{epsilonParam, x0LinearParam}, stopParams)
+)
+
+// checkLinearSystem проверяет размеры системы
+func checkLinearSystem(a [][]float64, b []float64) error {
+	if len(a) > maxLinearSize {
+		return fmt.Errorf("порядок системы не должен превышать %d", maxLinearSize)
+	}
+	return linalg.Validate(a, b)
+}
+
+// linearResidual возвращает ||Ax - b||₂
+func linearResidual(a [][]float64, b, x []float64) float64 {
+	return floats.Norm(linalg.Residual(a, b, x), 2)
+}
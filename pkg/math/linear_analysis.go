@@ -0,0 +1,174 @@
+package math
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/linalg"
+	"gonum.org/v1/gonum/floats"
+)
+
+// Готовые матрицы для демонстрации плохой обусловленности
+const (
+	PresetHilbert = "hilbert" // Матрица Гильберта порядка size
+)
+
+// Направления возмущения правой части в эксперименте
+const (
+	PerturbWorst = "worst" // Вдоль левого сингулярного вектора для σ_min: решение меняется сильнее всего
+	PerturbUser  = "user"  // Возмущение задано в запросе
+)
+
+// MatrixAnalysis - отчет об обусловленности матрицы и чувствительности
+// решения Ax = b к возмущению правой части
+type MatrixAnalysis struct {
+	Matrix [][]float64
+	Preset string
+	linalg.Analysis
+
+	// nil, если матрица вырождена и решение не определено
+	Perturbation *Perturbation
+}
+
+// Perturbation - результат эксперимента: решение Ax = b сравнивается с
+// решением A(x + δx) = b + δb. Относительная ошибка решения не превышает
+// cond₂(A) относительных ошибок правой части:
+//
+//	||δx|| / ||x|| <= cond₂(A) · ||δb|| / ||b||.
+type Perturbation struct {
+	Direction  string
+	B, DB      []float64
+	X          []float64 // Решение невозмущенной системы
+	XPerturbed []float64 // Решение возмущенной системы
+
+	RelDB         float64 // ||δb||₂ / ||b||₂
+	RelDX         float64 // ||δx||₂ / ||x||₂
+	Amplification float64 // RelDX / RelDB - во сколько раз возросла относительная ошибка
+	Bound         float64 // cond₂(A) · RelDB - теоретическая оценка сверху для RelDX
+}
+
+// analysisSpecs - параметры анализа матрицы
+var analysisSpecs = []ParamSpec{
+	{Name: "matrix", Type: ParamMatrix, Description: "Матрица по строкам; не нужна, если задан preset"},
+	{Name: "preset", Type: ParamString, Description: "Готовая матрица: hilbert - матрица Гильберта"},
+	{Name: "size", Type: ParamNumber, Default: 5.0, Description: "Порядок готовой матрицы"},
+	{Name: "b", Type: ParamVector, Description: "Правая часть для эксперимента; по умолчанию b = A·(1, ..., 1), т.е. точное решение - единичный вектор"},
+	{Name: "delta", Type: ParamNumber, Default: 1e-6, Description: "Относительная величина возмущения ||δb|| / ||b||"},
+	{Name: "db", Type: ParamVector, Description: "Возмущение правой части; если не задано, берется худшее направление с величиной delta"},
+}
+
+// AnalyzeMatrix вычисляет определитель, обратную матрицу, ранг и числа
+// обусловленности матрицы и показывает, как меняется решение Ax = b при
+// малом возмущении правой части.
+func AnalyzeMatrix(p Params) (MatrixAnalysis, error) {
+	var res MatrixAnalysis
+
+	checked, err := p.validate(analysisSpecs)
+	if err != nil {
+		return res, err
+	}
+
+	a, err := analysisMatrix(checked)
+	if err != nil {
+		return res, err
+	}
+	if len(a) > maxLinearSize {
+		return res, fmt.Errorf("порядок матрицы не должен превышать %d", maxLinearSize)
+	}
+	if err := linalg.ValidateSquare(a); err != nil {
+		return res, err
+	}
+	res.Matrix, res.Preset = a, checked.String("preset")
+
+	b := checked.Vector("b")
+	if b == nil {
+		b = linalg.MulVec(a, ones(len(a)))
+	}
+	if err := checkLinearSystem(a, b); err != nil {
+		return res, err
+	}
+
+	if res.Analysis, err = linalg.Analyze(a); err != nil {
+		return res, err
+	}
+	if res.Inverse == nil {
+		return res, nil
+	}
+
+	res.Perturbation, err = perturb(a, b, checked, res.Analysis)
+	return res, err
+}
+
+// analysisMatrix возвращает матрицу из запроса или готовую матрицу
+func analysisMatrix(p Params) ([][]float64, error) {
+	preset := p.String("preset")
+	switch {
+	case preset == "" && p.Has("matrix"):
+		return p.Matrix("matrix"), nil
+	case preset == "":
+		return nil, fmt.Errorf("нужно задать matrix или preset")
+	case p.Has("matrix"):
+		return nil, fmt.Errorf("matrix и preset нельзя задавать одновременно")
+	}
+
+	size := p.Float("size")
+	if size != math.Trunc(size) || size < 1 || size > maxLinearSize {
+		return nil, fmt.Errorf("порядок готовой матрицы должен быть целым числом от 1 до %d", maxLinearSize)
+	}
+	switch preset {
+	case PresetHilbert:
+		return linalg.Hilbert(int(size)), nil
+	}
+	return nil, fmt.Errorf("неизвестная готовая матрица %q, ожидается %q", preset, PresetHilbert)
+}
+
+// perturb решает исходную и возмущенную системы и сравнивает решения
+func perturb(a [][]float64, b []float64, p Params, an linalg.Analysis) (*Perturbation, error) {
+	normB := floats.Norm(b, 2)
+	if normB == 0 {
+		return nil, fmt.Errorf("для эксперимента нужна ненулевая правая часть")
+	}
+
+	res := &Perturbation{B: b, Direction: PerturbUser}
+	if p.Has("db") {
+		res.DB = p.Vector("db")
+		if len(res.DB) != len(b) {
+			return nil, fmt.Errorf("длина возмущения db (%d) не совпадает с порядком матрицы (%d)", len(res.DB), len(b))
+		}
+	} else {
+		delta := p.Float("delta")
+		if !(delta > 0) {
+			return nil, fmt.Errorf("delta должно быть положительным")
+		}
+		res.Direction = PerturbWorst
+		res.DB = slices.Clone(an.UMin)
+		floats.Scale(delta*normB, res.DB)
+	}
+
+	bp := slices.Clone(b)
+	floats.Add(bp, res.DB)
+
+	var err error
+	if res.X, err = linalg.Solve(a, b); err != nil {
+		return nil, err
+	}
+	if res.XPerturbed, err = linalg.Solve(a, bp); err != nil {
+		return nil, err
+	}
+
+	res.RelDB = floats.Norm(res.DB, 2) / normB
+	res.RelDX = floats.Distance(res.XPerturbed, res.X, 2) / floats.Norm(res.X, 2)
+	res.Amplification = res.RelDX / res.RelDB
+	res.Bound = an.Cond2 * res.RelDB
+	return res, nil
+}
+
+// ones возвращает вектор из n единиц
+func ones(n int) []float64 {
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = 1
+	}
+	return v
+}
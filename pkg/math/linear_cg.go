@@ -0,0 +1,109 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/linalg"
+	"gonum.org/v1/gonum/floats"
+)
+
+func init() {
+	RegisterLinear(LinearMethod{
+		Name:   "cg",
+		Title:  "Метод сопряженных градиентов",
+		Params: iterativeLinearParams,
+		New: func(p Params) (LinearSolver, error) {
+			return NewConjugateGradientCalculator(p.Matrix("matrix"), p.Vector("b"), p.Vector("x0"), p.Float("epsilon"))
+		},
+	})
+}
+
+// ConjugateGradientCalculator реализует метод сопряженных градиентов для
+// симметричных положительно определенных матриц. В точной арифметике метод
+// сходится не более чем за n итераций; собственный критерий - ||r||₂ < ε.
+type ConjugateGradientCalculator struct {
+	stopper
+
+	A       [][]float64
+	B       []float64
+	X0      []float64
+	Epsilon float64
+}
+
+func NewConjugateGradientCalculator(a [][]float64, b, x0 []float64, epsilon float64) (*ConjugateGradientCalculator, error) {
+	if x0 == nil {
+		x0 = make([]float64, len(b))
+	}
+	if len(x0) != len(b) {
+		return nil, fmt.Errorf("длина начального приближения (%d) не совпадает с порядком системы (%d)", len(x0), len(b))
+	}
+
+	return &ConjugateGradientCalculator{A: a, B: b, X0: x0, Epsilon: epsilon}, nil
+}
+
+func (c *ConjugateGradientCalculator) Calculate(ctx context.Context) (LinearResult, error) {
+	mt := newMeter(ctx)
+	res := LinearResult{Info: map[string]any{"size": len(c.A)}}
+
+	// Положительную определенность проверяет попытка разложения Холецкого
+	if _, err := linalg.Cholesky(c.A, c.B); err != nil {
+		return res, fmt.Errorf("метод сопряженных градиентов требует симметричную положительно определенную матрицу: %w", err)
+	}
+	res.Info["diagonal_dominance"] = linalg.DiagonalDominance(c.A)
+
+	x := slices.Clone(c.X0)
+	r := linalg.Residual(c.A, c.B, x)
+	p := slices.Clone(r)
+	ap := make([]float64, len(x))
+	rr := floats.Dot(r, r)
+
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		for k, row := range c.A {
+			ap[k] = floats.Dot(row, p)
+		}
+		pap := floats.Dot(p, ap)
+		if pap == 0 {
+			// Невязка уже нулевая: предыдущее приближение точное
+			res.X, res.Residual, res.StopReason = x, linearResidual(c.A, c.B, x), StopExact
+			return res, nil
+		}
+		alpha := rr / pap
+
+		prev := slices.Clone(x)
+		floats.AddScaled(x, alpha, p)
+		floats.AddScaled(r, -alpha, ap)
+		rrNew := floats.Dot(r, r)
+
+		step := floats.Distance(x, prev, math.Inf(1))
+		residual := math.Sqrt(rrNew)
+		if isBad(residual) || isBad(step) {
+			return res, fmt.Errorf("итерации расходятся: невязка на итерации %d не является конечным числом", i)
+		}
+		res.Trace = record(mt, res.Trace, LinearIteration{X: slices.Clone(x), Residual: residual, StepNorm: step})
+
+		if reason := c.Stop.done(residual < c.Epsilon, StopResidual, step, floats.Norm(x, math.Inf(1)), func(float64) float64 { return residual }); reason != "" {
+			// Рекуррентная невязка накапливает ошибки округления, в ответ идет истинная
+			res.X, res.Residual, res.StopReason = x, linearResidual(c.A, c.B, x), reason
+			return res, nil
+		}
+
+		// Новое направление A-сопряжено со всеми предыдущими
+		beta := rrNew / rr
+		for k := range p {
+			p[k] = r[k] + beta*p[k]
+		}
+		rr = rrNew
+	}
+
+	res.X, res.Residual = x, linearResidual(c.A, c.B, x)
+	res.StopReason = StopMaxIter
+	return res, fmt.Errorf("превышено максимальное количество итераций")
+}
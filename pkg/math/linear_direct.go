@@ -0,0 +1,80 @@
+package math
+
+import (
+	"context"
+	"slices"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/linalg"
+)
+
+func init() {
+	RegisterLinear(LinearMethod{
+		Name:   "gauss",
+		Title:  "Метод Гаусса с выбором ведущего элемента",
+		Params: append(slices.Clip(linearParams), pivotingParam),
+		New: func(p Params) (LinearSolver, error) {
+			pivoting := linalg.Pivoting(p.String("pivoting"))
+			return NewDirectLinearCalculator(p.Matrix("matrix"), p.Vector("b"), func(a [][]float64, b []float64) (linalg.Solution, error) {
+				return linalg.Gauss(a, b, pivoting)
+			}), nil
+		},
+	})
+	RegisterLinear(LinearMethod{
+		Name:   "lu",
+		Title:  "LU-разложение",
+		Params: linearParams,
+		New: func(p Params) (LinearSolver, error) {
+			return NewDirectLinearCalculator(p.Matrix("matrix"), p.Vector("b"), linalg.LU), nil
+		},
+	})
+	RegisterLinear(LinearMethod{
+		Name:   "cholesky",
+		Title:  "Метод Холецкого (квадратного корня)",
+		Params: linearParams,
+		New: func(p Params) (LinearSolver, error) {
+			return NewDirectLinearCalculator(p.Matrix("matrix"), p.Vector("b"), linalg.Cholesky), nil
+		},
+	})
+	RegisterLinear(LinearMethod{
+		Name:   "thomas",
+		Title:  "Метод прогонки (трехдиагональные системы)",
+		Params: linearParams,
+		New: func(p Params) (LinearSolver, error) {
+			return NewDirectLinearCalculator(p.Matrix("matrix"), p.Vector("b"), linalg.Thomas), nil
+		},
+	})
+}
+
+// DirectLinearCalculator - прямой метод решения СЛАУ из пакета linalg.
+// Прямые методы выполняют конечное число шагов, поэтому шаги передаются
+// обработчику после решения, а прерывание проверяется только перед ним.
+type DirectLinearCalculator struct {
+	A [][]float64
+	B []float64
+
+	solve func(a [][]float64, b []float64) (linalg.Solution, error)
+}
+
+func NewDirectLinearCalculator(a [][]float64, b []float64, solve func(a [][]float64, b []float64) (linalg.Solution, error)) *DirectLinearCalculator {
+	return &DirectLinearCalculator{A: a, B: b, solve: solve}
+}
+
+func (c *DirectLinearCalculator) Calculate(ctx context.Context) (LinearResult, error) {
+	mt := newMeter(ctx)
+	if err := mt.check(); err != nil {
+		return LinearResult{}, err
+	}
+
+	sol, err := c.solve(c.A, c.B)
+	res := LinearResult{Info: map[string]any{"size": len(c.A)}}
+	for _, step := range sol.Steps {
+		res.Steps = record(mt, res.Steps, step)
+	}
+	if err != nil {
+		return res, err
+	}
+
+	res.X, res.Det = sol.X, sol.Det
+	res.Residual = linearResidual(c.A, c.B, sol.X)
+	return res, nil
+}
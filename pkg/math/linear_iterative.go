@@ -0,0 +1,201 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/linalg"
+	"gonum.org/v1/gonum/floats"
+)
+
+func init() {
+	RegisterLinear(LinearMethod{
+		Name:   "jacobi",
+		Title:  "Метод Якоби (простой итерации)",
+		Params: iterativeLinearParams,
+		New: func(p Params) (LinearSolver, error) {
+			return NewRelaxationCalculator(RelaxJacobi, p.Matrix("matrix"), p.Vector("b"), p.Vector("x0"), p.Float("epsilon"), 1)
+		},
+	})
+	RegisterLinear(LinearMethod{
+		Name:   "seidel",
+		Title:  "Метод Гаусса - Зейделя",
+		Params: iterativeLinearParams,
+		New: func(p Params) (LinearSolver, error) {
+			return NewRelaxationCalculator(RelaxSeidel, p.Matrix("matrix"), p.Vector("b"), p.Vector("x0"), p.Float("epsilon"), 1)
+		},
+	})
+	RegisterLinear(LinearMethod{
+		Name:   "sor",
+		Title:  "Метод последовательной верхней релаксации (SOR)",
+		Params: append(slices.Clip(iterativeLinearParams), omegaParam),
+		New: func(p Params) (LinearSolver, error) {
+			// ω = 0 означает, что параметр релаксации нужно подобрать
+			return NewRelaxationCalculator(RelaxSOR, p.Matrix("matrix"), p.Vector("b"), p.Vector("x0"), p.Float("epsilon"), p.Float("omega"))
+		},
+	})
+}
+
+var omegaParam = ParamSpec{Name: "omega", Type: ParamNumber, Description: "Параметр релаксации 0 < ω < 2; если не задан, оценивается по спектральному радиусу матрицы Якоби"}
+
+// Варианты метода релаксации
+const (
+	RelaxJacobi = "jacobi" // Все компоненты считаются по предыдущему приближению
+	RelaxSeidel = "seidel" // Уже найденные компоненты сразу используются (ω = 1)
+	RelaxSOR    = "sor"    // Шаг Зейделя с весом ω
+)
+
+// Выводы предварительной проверки итерационного метода
+const VerdictDiverges = "diverges" // ρ(B) >= 1: итерации расходятся почти из любого x0
+
+// RelaxationCalculator реализует методы Якоби, Гаусса - Зейделя и релаксации.
+// Каждый из них - простая итерация x_n+1 = Bx_n + c, которая сходится из
+// любого начального приближения тогда и только тогда, когда ρ(B) < 1.
+// Перед итерациями проверяется диагональное преобладание и вычисляется
+// спектральный радиус матрицы B.
+type RelaxationCalculator struct {
+	stopper
+
+	A       [][]float64
+	B       []float64
+	X0      []float64
+	Epsilon float64
+	Omega   float64 // 0 - подобрать по спектральному радиусу матрицы Якоби
+
+	Variant string
+}
+
+func NewRelaxationCalculator(variant string, a [][]float64, b, x0 []float64, epsilon, omega float64) (*RelaxationCalculator, error) {
+	if err := linalg.CheckDiagonal(a); err != nil {
+		return nil, err
+	}
+	if omega != 0 && (omega <= 0 || omega >= 2) {
+		return nil, fmt.Errorf("параметр релаксации должен лежать в интервале (0, 2)")
+	}
+	if x0 == nil {
+		x0 = make([]float64, len(b))
+	}
+	if len(x0) != len(b) {
+		return nil, fmt.Errorf("длина начального приближения (%d) не совпадает с порядком системы (%d)", len(x0), len(b))
+	}
+
+	return &RelaxationCalculator{A: a, B: b, X0: x0, Epsilon: epsilon, Omega: omega, Variant: variant}, nil
+}
+
+func (c *RelaxationCalculator) Calculate(ctx context.Context) (LinearResult, error) {
+	mt := newMeter(ctx)
+	res := LinearResult{Info: map[string]any{"size": len(c.A)}}
+
+	omega, err := c.precheck(res.Info)
+	if err != nil {
+		return res, err
+	}
+
+	x := slices.Clone(c.X0)
+	prev := make([]float64, len(x))
+
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		copy(prev, x)
+		c.sweep(x, prev, omega)
+
+		step := floats.Distance(x, prev, math.Inf(1))
+		residual := linearResidual(c.A, c.B, x)
+		if isBad(residual) || isBad(step) {
+			return res, fmt.Errorf("итерации расходятся: невязка на итерации %d не является конечным числом", i)
+		}
+		res.Trace = record(mt, res.Trace, LinearIteration{X: slices.Clone(x), Residual: residual, StepNorm: step})
+
+		if reason := c.Stop.done(step < c.Epsilon, StopStep, step, floats.Norm(x, math.Inf(1)), func(float64) float64 { return residual }); reason != "" {
+			res.X, res.Residual, res.StopReason = x, residual, reason
+			return res, nil
+		}
+	}
+
+	res.X, res.Residual = x, linearResidual(c.A, c.B, x)
+	res.StopReason = StopMaxIter
+	return res, fmt.Errorf("превышено максимальное количество итераций")
+}
+
+// sweep выполняет одну итерацию: x - новое приближение (на входе равно prev)
+func (c *RelaxationCalculator) sweep(x, prev []float64, omega float64) {
+	for i, row := range c.A {
+		s := c.B[i]
+		for j, v := range row {
+			if j == i {
+				continue
+			}
+			// У Якоби все компоненты берутся из prev, у Зейделя и SOR
+			// компоненты j < i уже обновлены в x
+			if c.Variant == RelaxJacobi {
+				s -= v * prev[j]
+			} else {
+				s -= v * x[j]
+			}
+		}
+		gs := s / row[i]
+		x[i] = prev[i] + omega*(gs-prev[i])
+	}
+}
+
+// precheck записывает в info диагональное преобладание, спектральный радиус
+// матрицы итераций и вывод о сходимости, а для SOR без заданного ω подбирает его
+func (c *RelaxationCalculator) precheck(info map[string]any) (float64, error) {
+	dominance := linalg.DiagonalDominance(c.A)
+	info["diagonal_dominance"] = dominance
+
+	jacobi := linalg.JacobiMatrix(c.A)
+	rhoJ, err := linalg.SpectralRadius(jacobi)
+	if err != nil {
+		return 0, err
+	}
+
+	omega := 1.0
+	var rho float64
+	switch c.Variant {
+	case RelaxJacobi:
+		rho = rhoJ
+	default:
+		if c.Variant == RelaxSOR {
+			omega = c.Omega
+			info["omega_source"] = "user"
+			if omega == 0 {
+				omega = linalg.OptimalOmega(rhoJ)
+				info["omega_source"] = "auto"
+			}
+			info["omega"] = omega
+		}
+		b, err := linalg.SORMatrix(c.A, omega)
+		if err != nil {
+			return 0, err
+		}
+		if rho, err = linalg.SpectralRadius(b); err != nil {
+			return 0, err
+		}
+	}
+	info["spectral_radius"] = rho
+
+	switch {
+	case rho < 1:
+		info["verdict"] = VerdictConverges
+		info["verdict_reason"] = fmt.Sprintf("ρ(B) = %.6g < 1", rho)
+		// Погрешность убывает примерно как ρⁿ
+		if rho > 0 {
+			info["apriori_iterations"] = int(math.Ceil(math.Log(c.Epsilon) / math.Log(rho)))
+		}
+	default:
+		info["verdict"] = VerdictDiverges
+		info["verdict_reason"] = fmt.Sprintf("ρ(B) = %.6g >= 1", rho)
+	}
+	if dominance == linalg.DominanceStrict && c.Variant != RelaxSOR {
+		info["verdict_reason"] = fmt.Sprintf("%s, строгое диагональное преобладание", info["verdict_reason"])
+	}
+
+	return omega, nil
+}
@@ -0,0 +1,138 @@
+package mathutils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Node - узел дерева разобранного выражения
+type Node interface {
+	// String возвращает запись выражения в синтаксисе формул (со знаком '^' для степени)
+	String() string
+}
+
+// Num - числовая константа
+type Num struct {
+	Value float64
+}
+
+// Var - переменная или именованная константа (x, pi, e)
+type Var struct {
+	Name string
+	Pos  int // Позиция в исходной формуле, для сообщений об ошибках
+}
+
+// Unary - унарный минус
+type Unary struct {
+	Op rune
+	X  Node
+}
+
+// Binary - бинарная операция: '+', '-', '*', '/', '^'
+type Binary struct {
+	Op   rune
+	L, R Node
+}
+
+// Call - вызов функции, например sin(x)
+type Call struct {
+	Name string
+	Args []Node
+	Pos  int // Позиция в исходной формуле, для сообщений об ошибках
+}
+
+// Приоритеты операций при печати выражения
+const (
+	precAdd   = 1
+	precMul   = 2
+	precUnary = 3
+	precPow   = 4
+	precAtom  = 5
+)
+
+func (n *Num) String() string    { s, _ := format(n); return s }
+func (n *Var) String() string    { return n.Name }
+func (n *Unary) String() string  { s, _ := format(n); return s }
+func (n *Binary) String() string { s, _ := format(n); return s }
+func (n *Call) String() string   { s, _ := format(n); return s }
+
+// format печатает узел и возвращает приоритет получившейся записи,
+// чтобы родитель мог решить, нужны ли скобки
+func format(n Node) (string, int) {
+	switch n := n.(type) {
+	case *Num:
+		s := strconv.FormatFloat(n.Value, 'f', -1, 64)
+		if n.Value < 0 {
+			return s, precUnary
+		}
+		return s, precAtom
+	case *Var:
+		return n.Name, precAtom
+	case *Unary:
+		// Унарный минус слабее степени: -x^2, но -(2*x) и -(x + 1)
+		return string(n.Op) + wrap(n.X, func(p int) bool { return p < precPow }), precUnary
+	case *Binary:
+		switch n.Op {
+		case '+', '-':
+			l := wrap(n.L, func(p int) bool { return p < precAdd })
+			r := wrap(n.R, func(p int) bool { return p <= precAdd || p == precUnary })
+			return l + " " + string(n.Op) + " " + r, precAdd
+		case '*', '/':
+			l := wrap(n.L, func(p int) bool { return p < precMul })
+			r := wrap(n.R, func(p int) bool { return p <= precMul || p == precUnary })
+			return l + string(n.Op) + r, precMul
+		case '^':
+			l := wrap(n.L, func(p int) bool { return p < precAtom })
+			r := wrap(n.R, func(p int) bool { return p < precAtom })
+			return l + "^" + r, precPow
+		}
+	case *Call:
+		args := make([]string, len(n.Args))
+		for i, a := range n.Args {
+			args[i] = a.String()
+		}
+		return n.Name + "(" + strings.Join(args, ", ") + ")", precAtom
+	}
+	return "", precAtom
+}
+
+// wrap печатает узел и заключает его в скобки, если этого требует условие
+func wrap(n Node, needParens func(prec int) bool) string {
+	s, p := format(n)
+	if needParens(p) {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// FreeVariables возвращает имена переменных выражения, которые не являются
+// параметрами или константами, в порядке первого появления
+func FreeVariables(n Node, params map[string]float64) []string {
+	var names []string
+	seen := make(map[string]bool)
+
+	var walk func(n Node)
+	walk = func(n Node) {
+		switch n := n.(type) {
+		case *Var:
+			_, isParam := params[n.Name]
+			_, isConst := constants[n.Name]
+			if !isParam && !isConst && !seen[n.Name] {
+				seen[n.Name] = true
+				names = append(names, n.Name)
+			}
+		case *Unary:
+			walk(n.X)
+		case *Binary:
+			walk(n.L)
+			walk(n.R)
+		case *Call:
+			for _, a := range n.Args {
+				walk(a)
+			}
+		}
+	}
+	walk(n)
+
+	return names
+}
@@ -0,0 +1,300 @@
+package mathutils
+
+import (
+	"fmt"
+	"math"
+)
+
+// opcode - инструкция стековой машины, в которую компилируется формула
+type opcode uint8
+
+const (
+	opConst opcode = iota // Положить на стек константу consts[arg]
+	opVar                 // Положить на стек переменную vars[arg]
+	opNeg
+	opAdd
+	opSub
+	opMul
+	opDiv
+	opPow
+	opCall  // Применить функцию funcs[arg] к вершине стека
+	opCall2 // Применить функцию funcs2[arg] к двум верхним значениям стека
+)
+
+type instr struct {
+	op  opcode
+	arg int
+}
+
+// Размер стека, который выделяется на стеке горутины. Формулы глубже
+// этого встречаются редко, для них стек выделяется в куче.
+const inlineStack = 32
+
+// Program - формула, скомпилированная в байткод для быстрого вычисления.
+// Eval не выделяет память, поэтому Program можно вызывать в горячих циклах методов.
+type Program struct {
+	code     []instr
+	consts   []float64
+	funcs    []func(float64) float64
+	funcs2   []func(float64, float64) float64
+	vars     []string
+	maxStack int
+
+	// Комплексные аналоги функций с теми же индексами, что funcs и funcs2.
+	// realOnly - первая функция формулы, у которой комплексного аналога нет.
+	cfuncs   []func(complex128) complex128
+	cfuncs2  []func(complex128, complex128) complex128
+	realOnly string
+}
+
+// Vars возвращает имена переменных в порядке, в котором их ожидает Eval
+func (p *Program) Vars() []string {
+	return p.vars
+}
+
+// ParseFormula разбирает формулу функции одной переменной x и компилирует ее.
+// params - значения именованных параметров формулы, например {"a": 2}.
+func ParseFormula(formula string, params map[string]float64) (*Program, error) {
+	tree, err := ParseTree(formula)
+	if err != nil {
+		return nil, err
+	}
+	return Compile(tree, formula, params, "x")
+}
+
+// ParseFormulaXY разбирает правую часть f(x, y) дифференциального уравнения
+// y' = f(x, y) и компилирует ее с переменными x и y.
+func ParseFormulaXY(formula string, params map[string]float64) (*Program, error) {
+	tree, err := ParseTree(formula)
+	if err != nil {
+		return nil, err
+	}
+	return Compile(tree, formula, params, "x", "y")
+}
+
+// Compile компилирует дерево выражения. vars - имена переменных, значения
+// которых передаются в Eval в том же порядке; params - значения именованных
+// параметров, которые подставляются в формулу как константы; source -
+// исходная формула для сообщений об ошибках.
+func Compile(n Node, source string, params map[string]float64, vars ...string) (*Program, error) {
+	c := &compiler{
+		prog:     &Program{vars: vars},
+		source:   source,
+		params:   params,
+		varIndex: make(map[string]int, len(vars)),
+		funcIdx:  make(map[string]int),
+		func2Idx: make(map[string]int),
+	}
+	for i, v := range vars {
+		c.varIndex[v] = i
+	}
+	for name := range params {
+		if err := checkParamName(name, c.varIndex); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.emit(n); err != nil {
+		return nil, err
+	}
+	return c.prog, nil
+}
+
+type compiler struct {
+	prog     *Program
+	source   string
+	params   map[string]float64
+	varIndex map[string]int
+	funcIdx  map[string]int
+	func2Idx map[string]int
+	depth    int
+}
+
+func (c *compiler) push(in instr, stackDelta int) {
+	c.prog.code = append(c.prog.code, in)
+	c.depth += stackDelta
+	if c.depth > c.prog.maxStack {
+		c.prog.maxStack = c.depth
+	}
+}
+
+func (c *compiler) constant(v float64) {
+	c.prog.consts = append(c.prog.consts, v)
+	c.push(instr{op: opConst, arg: len(c.prog.consts) - 1}, 1)
+}
+
+func (c *compiler) emit(n Node) error {
+	switch n := n.(type) {
+	case *Num:
+		c.constant(n.Value)
+
+	case *Var:
+		// Переменные имеют приоритет над параметрами. Параметры с именами
+		// констант запрещены: символьное дифференцирование считает e числом Эйлера
+		if idx, ok := c.varIndex[n.Name]; ok {
+			c.push(instr{op: opVar, arg: idx}, 1)
+			return nil
+		}
+		if v, ok := c.params[n.Name]; ok {
+			c.constant(v)
+			return nil
+		}
+		if v, ok := constants[n.Name]; ok {
+			c.constant(v)
+			return nil
+		}
+		return &SyntaxError{Source: c.source, Pos: n.Pos, Msg: fmt.Sprintf("неизвестная переменная %q", n.Name)}
+
+	case *Unary:
+		if err := c.emit(n.X); err != nil {
+			return err
+		}
+		c.push(instr{op: opNeg}, 0)
+
+	case *Binary:
+		if err := c.emit(n.L); err != nil {
+			return err
+		}
+		if err := c.emit(n.R); err != nil {
+			return err
+		}
+		var op opcode
+		switch n.Op {
+		case '+':
+			op = opAdd
+		case '-':
+			op = opSub
+		case '*':
+			op = opMul
+		case '/':
+			op = opDiv
+		case '^':
+			op = opPow
+		default:
+			return fmt.Errorf("неизвестная операция %q", n.Op)
+		}
+		c.push(instr{op: op}, -1)
+
+	case *Call:
+		if err := checkArity(n.Name, len(n.Args)); err != nil {
+			if !isFunction(n.Name) {
+				err = fmt.Errorf("неизвестная функция %q", n.Name)
+			}
+			return &SyntaxError{Source: c.source, Pos: n.Pos, Msg: err.Error()}
+		}
+		for _, a := range n.Args {
+			if err := c.emit(a); err != nil {
+				return err
+			}
+		}
+
+		if len(n.Args) == 2 {
+			idx, ok := c.func2Idx[n.Name]
+			if !ok {
+				c.prog.funcs2 = append(c.prog.funcs2, functions2[n.Name])
+				c.prog.cfuncs2 = append(c.prog.cfuncs2, complexFunctions2[n.Name])
+				c.markRealOnly(n.Name, complexFunctions2[n.Name] == nil)
+				idx = len(c.prog.funcs2) - 1
+				c.func2Idx[n.Name] = idx
+			}
+			c.push(instr{op: opCall2, arg: idx}, -1)
+			return nil
+		}
+
+		idx, ok := c.funcIdx[n.Name]
+		if !ok {
+			c.prog.funcs = append(c.prog.funcs, functions[n.Name])
+			c.prog.cfuncs = append(c.prog.cfuncs, complexFunctions[n.Name])
+			c.markRealOnly(n.Name, complexFunctions[n.Name] == nil)
+			idx = len(c.prog.funcs) - 1
+			c.funcIdx[n.Name] = idx
+		}
+		c.push(instr{op: opCall, arg: idx}, 0)
+
+	default:
+		return fmt.Errorf("неподдерживаемый узел выражения %T", n)
+	}
+
+	return nil
+}
+
+// markRealOnly запоминает первую функцию без комплексного аналога
+func (c *compiler) markRealOnly(name string, realOnly bool) {
+	if realOnly && c.prog.realOnly == "" {
+		c.prog.realOnly = name
+	}
+}
+
+// checkParamName проверяет, что имя параметра можно использовать в формуле
+func checkParamName(name string, vars map[string]int) error {
+	if !isIdent(name) {
+		return fmt.Errorf("некорректное имя параметра %q", name)
+	}
+	if _, ok := vars[name]; ok {
+		return fmt.Errorf("имя параметра %q совпадает с именем переменной", name)
+	}
+	if isFunction(name) {
+		return fmt.Errorf("имя параметра %q совпадает с именем функции", name)
+	}
+	if _, ok := constants[name]; ok {
+		return fmt.Errorf("имя параметра %q совпадает с именем константы", name)
+	}
+	return nil
+}
+
+// Eval вычисляет формулу. vars - значения переменных в порядке Vars().
+func (p *Program) Eval(vars []float64) float64 {
+	var buf [inlineStack]float64
+	stack := buf[:]
+	if p.maxStack > inlineStack {
+		stack = make([]float64, p.maxStack)
+	}
+
+	sp := -1
+	for _, in := range p.code {
+		switch in.op {
+		case opConst:
+			sp++
+			stack[sp] = p.consts[in.arg]
+		case opVar:
+			sp++
+			stack[sp] = vars[in.arg]
+		case opNeg:
+			stack[sp] = -stack[sp]
+		case opAdd:
+			sp--
+			stack[sp] += stack[sp+1]
+		case opSub:
+			sp--
+			stack[sp] -= stack[sp+1]
+		case opMul:
+			sp--
+			stack[sp] *= stack[sp+1]
+		case opDiv:
+			sp--
+			stack[sp] /= stack[sp+1]
+		case opPow:
+			sp--
+			stack[sp] = math.Pow(stack[sp], stack[sp+1])
+		case opCall:
+			stack[sp] = p.funcs[in.arg](stack[sp])
+		case opCall2:
+			sp--
+			stack[sp] = p.funcs2[in.arg](stack[sp], stack[sp+1])
+		}
+	}
+
+	return stack[0]
+}
+
+// Eval1 вычисляет формулу одной переменной
+func (p *Program) Eval1(x float64) float64 {
+	vars := [1]float64{x}
+	return p.Eval(vars[:])
+}
+
+// Eval2 вычисляет формулу двух переменных, например f(x, y)
+func (p *Program) Eval2(x, y float64) float64 {
+	vars := [2]float64{x, y}
+	return p.Eval(vars[:])
+}
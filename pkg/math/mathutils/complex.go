@@ -0,0 +1,118 @@
+package mathutils
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// complexFunctions - комплексные аналоги функций одного аргумента (главные ветви).
+// У floor, ceil и sign аналогов нет, такие формулы вычисляются только над вещественными числами.
+var complexFunctions = map[string]func(complex128) complex128{
+	"ln":   cmplx.Log,
+	"log":  cmplx.Log10,
+	"sin":  cmplx.Sin,
+	"cos":  cmplx.Cos,
+	"tan":  cmplx.Tan,
+	"cot":  cmplx.Cot,
+	"sec":  func(z complex128) complex128 { return 1 / cmplx.Cos(z) },
+	"asin": cmplx.Asin,
+	"acos": cmplx.Acos,
+	"atan": cmplx.Atan,
+	"sinh": cmplx.Sinh,
+	"cosh": cmplx.Cosh,
+	"tanh": cmplx.Tanh,
+	"sqrt": cmplx.Sqrt,
+	"cbrt": func(z complex128) complex128 { return cpow(z, 1.0/3) },
+	"abs":  func(z complex128) complex128 { return complex(cmplx.Abs(z), 0) },
+	"exp":  cmplx.Exp,
+}
+
+// complexFunctions2 - комплексные аналоги функций двух аргументов
+var complexFunctions2 = map[string]func(complex128, complex128) complex128{
+	"log": func(z, base complex128) complex128 { return cmplx.Log(z) / cmplx.Log(base) },
+	"pow": cpow,
+}
+
+// cpow возводит комплексное число в степень. Целые показатели вычисляются
+// умножением: это точнее и быстрее, чем через exp(w*ln z), и дает
+// точный ноль в нуле, что важно для метода Ньютона.
+func cpow(z, w complex128) complex128 {
+	if imag(w) == 0 {
+		if k := real(w); k == math.Trunc(k) && math.Abs(k) <= 64 {
+			n := int(math.Abs(k))
+			res := complex(1, 0)
+			for base := z; n > 0; n >>= 1 {
+				if n&1 == 1 {
+					res *= base
+				}
+				base *= base
+			}
+			if k < 0 {
+				return 1 / res
+			}
+			return res
+		}
+	}
+	return cmplx.Pow(z, w)
+}
+
+// CheckComplex проверяет, что формулу можно вычислять над комплексными числами
+func (p *Program) CheckComplex() error {
+	if p.realOnly != "" {
+		return fmt.Errorf("функция %s не определена для комплексных чисел", p.realOnly)
+	}
+	return nil
+}
+
+// EvalComplex вычисляет формулу над комплексными числами по тому же байткоду,
+// что и Eval. Перед использованием формулу нужно проверить CheckComplex.
+func (p *Program) EvalComplex(vars []complex128) complex128 {
+	var buf [inlineStack]complex128
+	stack := buf[:]
+	if p.maxStack > inlineStack {
+		stack = make([]complex128, p.maxStack)
+	}
+
+	sp := -1
+	for _, in := range p.code {
+		switch in.op {
+		case opConst:
+			sp++
+			stack[sp] = complex(p.consts[in.arg], 0)
+		case opVar:
+			sp++
+			stack[sp] = vars[in.arg]
+		case opNeg:
+			stack[sp] = -stack[sp]
+		case opAdd:
+			sp--
+			stack[sp] += stack[sp+1]
+		case opSub:
+			sp--
+			stack[sp] -= stack[sp+1]
+		case opMul:
+			sp--
+			stack[sp] *= stack[sp+1]
+		case opDiv:
+			sp--
+			stack[sp] /= stack[sp+1]
+		case opPow:
+			sp--
+			stack[sp] = cpow(stack[sp], stack[sp+1])
+		case opCall:
+			stack[sp] = p.cfuncs[in.arg](stack[sp])
+		case opCall2:
+			sp--
+			stack[sp] = p.cfuncs2[in.arg](stack[sp], stack[sp+1])
+		}
+	}
+
+	return stack[0]
+}
+
+// EvalComplex1 вычисляет формулу одной комплексной переменной
+func (p *Program) EvalComplex1(z complex128) complex128 {
+	vars := [1]complex128{z}
+	return p.EvalComplex(vars[:])
+}
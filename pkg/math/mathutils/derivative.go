@@ -0,0 +1,210 @@
+package mathutils
+
+import (
+	"fmt"
+	"math"
+)
+
+// Derivative символьно дифференцирует выражение по переменной v и упрощает результат.
+// Поддерживаются арифметические операции, степени и все функции формул.
+func Derivative(n Node, v string) (Node, error) {
+	d, err := derive(n, v)
+	if err != nil {
+		return nil, err
+	}
+	return Simplify(d), nil
+}
+
+// DerivativeFormula возвращает производную формулы по x в виде строки
+func DerivativeFormula(formula string) (string, error) {
+	tree, err := ParseTree(formula)
+	if err != nil {
+		return "", err
+	}
+
+	d, err := Derivative(tree, "x")
+	if err != nil {
+		return "", err
+	}
+	return d.String(), nil
+}
+
+// dependsOn проверяет, входит ли переменная v в выражение
+func dependsOn(n Node, v string) bool {
+	switch n := n.(type) {
+	case *Var:
+		return n.Name == v
+	case *Unary:
+		return dependsOn(n.X, v)
+	case *Binary:
+		return dependsOn(n.L, v) || dependsOn(n.R, v)
+	case *Call:
+		for _, a := range n.Args {
+			if dependsOn(a, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Короткие конструкторы узлов, чтобы правила дифференцирования читались как формулы
+func num(v float64) Node            { return &Num{Value: v} }
+func neg(x Node) Node               { return &Unary{Op: '-', X: x} }
+func add(l, r Node) Node            { return &Binary{Op: '+', L: l, R: r} }
+func sub(l, r Node) Node            { return &Binary{Op: '-', L: l, R: r} }
+func mul(l, r Node) Node            { return &Binary{Op: '*', L: l, R: r} }
+func div(l, r Node) Node            { return &Binary{Op: '/', L: l, R: r} }
+func pow(l, r Node) Node            { return &Binary{Op: '^', L: l, R: r} }
+func call(name string, x Node) Node { return &Call{Name: name, Args: []Node{x}} }
+
+func derive(n Node, v string) (Node, error) {
+	if !dependsOn(n, v) {
+		return num(0), nil
+	}
+
+	switch n := n.(type) {
+	case *Var:
+		return num(1), nil
+
+	case *Unary:
+		dx, err := derive(n.X, v)
+		if err != nil {
+			return nil, err
+		}
+		return neg(dx), nil
+
+	case *Binary:
+		dl, err := derive(n.L, v)
+		if err != nil {
+			return nil, err
+		}
+		dr, err := derive(n.R, v)
+		if err != nil {
+			return nil, err
+		}
+
+		switch n.Op {
+		case '+':
+			return add(dl, dr), nil
+		case '-':
+			return sub(dl, dr), nil
+		case '*':
+			// (uv)' = u'v + uv'
+			return add(mul(dl, n.R), mul(n.L, dr)), nil
+		case '/':
+			// (u/c)' = u'/c
+			if !dependsOn(n.R, v) {
+				return div(dl, n.R), nil
+			}
+			// (u/v)' = (u'v - uv') / v^2
+			return div(sub(mul(dl, n.R), mul(n.L, dr)), pow(n.R, num(2))), nil
+		case '^':
+			return derivePow(n, dl, dr, v), nil
+		}
+
+	case *Call:
+		if len(n.Args) == 2 {
+			// Функции двух аргументов выражаем через операции и дифференцируем их
+			expanded, ok := expandCall2(n)
+			if !ok {
+				return nil, fmt.Errorf("не известна производная функции %s", n.Name)
+			}
+			return derive(expanded, v)
+		}
+		u := n.Args[0]
+		du, err := derive(u, v)
+		if err != nil {
+			return nil, err
+		}
+
+		// Цепное правило: f(u)' = f'(u) * u'
+		var outer Node
+		switch n.Name {
+		case "ln":
+			outer = div(num(1), u)
+		case "log":
+			outer = div(num(1), mul(u, call("ln", num(10))))
+		case "sin":
+			outer = call("cos", u)
+		case "cos":
+			outer = neg(call("sin", u))
+		case "tan":
+			outer = div(num(1), pow(call("cos", u), num(2)))
+		case "cot":
+			outer = neg(div(num(1), pow(call("sin", u), num(2))))
+		case "sec":
+			outer = mul(call("sec", u), call("tan", u))
+		case "asin":
+			outer = div(num(1), call("sqrt", sub(num(1), pow(u, num(2)))))
+		case "acos":
+			outer = neg(div(num(1), call("sqrt", sub(num(1), pow(u, num(2))))))
+		case "atan":
+			outer = div(num(1), add(num(1), pow(u, num(2))))
+		case "sinh":
+			outer = call("cosh", u)
+		case "cosh":
+			outer = call("sinh", u)
+		case "tanh":
+			outer = div(num(1), pow(call("cosh", u), num(2)))
+		case "sqrt":
+			outer = div(num(1), mul(num(2), call("sqrt", u)))
+		case "cbrt":
+			outer = div(num(1), mul(num(3), pow(call("cbrt", u), num(2))))
+		case "abs":
+			outer = div(u, call("abs", u))
+		case "exp":
+			outer = call("exp", u)
+		case "sign", "floor", "ceil":
+			// Кусочно-постоянные функции: производная равна нулю везде, где определена
+			return num(0), nil
+		default:
+			return nil, fmt.Errorf("не известна производная функции %s", n.Name)
+		}
+		return mul(outer, du), nil
+	}
+
+	return nil, fmt.Errorf("неподдерживаемый узел выражения %T", n)
+}
+
+// expandCall2 выражает функцию двух аргументов через операции и функции одного аргумента
+func expandCall2(n *Call) (Node, bool) {
+	u, w := n.Args[0], n.Args[1]
+	switch n.Name {
+	case "log":
+		// log(u, b) = ln(u) / ln(b)
+		return div(call("ln", u), call("ln", w)), true
+	case "pow":
+		return pow(u, w), true
+	case "min":
+		// min(u, w) = (u + w - |u - w|) / 2
+		return div(sub(add(u, w), call("abs", sub(u, w))), num(2)), true
+	case "max":
+		// max(u, w) = (u + w + |u - w|) / 2
+		return div(add(add(u, w), call("abs", sub(u, w))), num(2)), true
+	}
+	return nil, false
+}
+
+// derivePow дифференцирует степень u^w с учетом того, от чего зависит переменная
+func derivePow(n *Binary, du, dw Node, v string) Node {
+	u, w := n.L, n.R
+
+	switch {
+	case !dependsOn(w, v):
+		// (u^c)' = c * u^(c-1) * u'
+		return mul(mul(w, pow(u, sub(w, num(1)))), du)
+	case !dependsOn(u, v):
+		// (c^w)' = c^w * ln(c) * w'
+		if base, ok := u.(*Var); ok && base.Name == "e" {
+			return mul(n, dw)
+		}
+		if base, ok := u.(*Num); ok && base.Value == math.E {
+			return mul(n, dw)
+		}
+		return mul(mul(n, call("ln", u)), dw)
+	default:
+		// (u^w)' = u^w * (w' ln(u) + w u' / u)
+		return mul(n, add(mul(dw, call("ln", u)), div(mul(w, du), u)))
+	}
+}
@@ -0,0 +1,81 @@
+package mathutils
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// functions - встроенные функции одного аргумента
+var functions = map[string]func(float64) float64{
+	"ln":    math.Log,
+	"log":   math.Log10,
+	"sin":   math.Sin,
+	"cos":   math.Cos,
+	"tan":   math.Tan,
+	"cot":   func(x float64) float64 { return 1 / math.Tan(x) },
+	"sec":   func(x float64) float64 { return 1 / math.Cos(x) },
+	"asin":  math.Asin,
+	"acos":  math.Acos,
+	"atan":  math.Atan,
+	"sinh":  math.Sinh,
+	"cosh":  math.Cosh,
+	"tanh":  math.Tanh,
+	"sqrt":  math.Sqrt,
+	"cbrt":  math.Cbrt,
+	"abs":   math.Abs,
+	"exp":   math.Exp,
+	"sign":  sign,
+	"floor": math.Floor,
+	"ceil":  math.Ceil,
+}
+
+// functions2 - встроенные функции двух аргументов
+var functions2 = map[string]func(float64, float64) float64{
+	"log": func(x, base float64) float64 { return math.Log(x) / math.Log(base) }, // log(x, основание)
+	"pow": math.Pow,
+	"min": math.Min,
+	"max": math.Max,
+}
+
+// constants - именованные константы, доступные в любой формуле
+var constants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+// sign возвращает знак числа: -1, 0 или 1
+func sign(x float64) float64 {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return x
+}
+
+// isFunction проверяет, что имя - встроенная функция
+func isFunction(name string) bool {
+	_, ok1 := functions[name]
+	_, ok2 := functions2[name]
+	return ok1 || ok2
+}
+
+// checkArity проверяет число аргументов при вызове встроенной функции
+func checkArity(name string, n int) error {
+	var allowed []string
+	if _, ok := functions[name]; ok {
+		if n == 1 {
+			return nil
+		}
+		allowed = append(allowed, "1")
+	}
+	if _, ok := functions2[name]; ok {
+		if n == 2 {
+			return nil
+		}
+		allowed = append(allowed, "2")
+	}
+	return fmt.Errorf("функция %s ожидает аргументов: %s, передано: %d", name, strings.Join(allowed, " или "), n)
+}
@@ -0,0 +1,137 @@
+package mathutils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind - вид лексемы формулы
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp     // + - * / ^ (а также ** как синоним ^)
+	tokLParen // (
+	tokRParen // )
+	tokComma  // ,
+	tokEquals // =
+)
+
+// token - лексема с позицией начала в исходной строке (в символах, с нуля)
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	pos  int
+}
+
+// SyntaxError - ошибка разбора формулы с указанием места ошибки
+type SyntaxError struct {
+	Source string // Исходная формула
+	Pos    int    // Позиция ошибки в символах, с нуля
+	Msg    string
+}
+
+// Column возвращает номер столбца ошибки, начиная с единицы
+func (e *SyntaxError) Column() int {
+	return e.Pos + 1
+}
+
+// Error печатает сообщение, формулу и каретку под местом ошибки:
+//
+//	ошибка в формуле (столбец 5): ожидалось выражение
+//	  x^2 +
+//	      ^
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("ошибка в формуле (столбец %d): %s\n  %s\n  %s^",
+		e.Column(), e.Msg, e.Source, strings.Repeat(" ", e.Pos))
+}
+
+// isIdent проверяет, что строка является идентификатором формулы
+func isIdent(s string) bool {
+	for i, r := range []rune(s) {
+		if !(unicode.IsLetter(r) || r == '_' || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// tokenize разбивает формулу на лексемы
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			// Экспоненциальная запись: 1e-3, 2.5E+4. Одинокая "e" после числа
+			// к числу не относится.
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				j := i + 1
+				if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+					j++
+				}
+				if j < len(runes) && unicode.IsDigit(runes[j]) {
+					for j < len(runes) && unicode.IsDigit(runes[j]) {
+						j++
+					}
+					i = j
+				}
+			}
+			text := string(runes[start:i])
+			v, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, &SyntaxError{Source: src, Pos: start, Msg: fmt.Sprintf("некорректное число %q", text)}
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: text, num: v, pos: start})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: string(runes[start:i]), pos: start})
+
+		case r == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			tokens = append(tokens, token{kind: tokOp, text: "^", pos: i})
+			i += 2
+
+		case strings.ContainsRune("+-*/^", r):
+			tokens = append(tokens, token{kind: tokOp, text: string(r), pos: i})
+			i++
+
+		case r == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokComma, text: ",", pos: i})
+			i++
+		case r == '=':
+			tokens = append(tokens, token{kind: tokEquals, text: "=", pos: i})
+			i++
+
+		default:
+			return nil, &SyntaxError{Source: src, Pos: i, Msg: fmt.Sprintf("недопустимый символ %q", r)}
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokEOF, pos: utf8.RuneCountInString(src)})
+	return tokens, nil
+}
@@ -1,82 +1,212 @@
 package mathutils
 
-import (
-	"fmt"
-	"math"
-	"regexp"
-	"strings"
+import "fmt"
 
-	"github.com/Knetic/govaluate"
+// Силы связывания операторов для разбора Пратта. Чем больше число,
+// тем сильнее оператор притягивает операнды.
+const (
+	bpNone   = 0
+	bpAdd    = 10 // + -
+	bpMul    = 20 // * / и неявное умножение: 2x, x(x-1)
+	bpPrefix = 25 // унарный минус: слабее степени, сильнее умножения
+	bpPow    = 30 // ^ (правоассоциативная)
+	bpArg    = 50 // аргумент функции без скобок: ln x
 )
 
-// ParseFormula обрабатывает строку с функцией:
-// 1. Если есть знак "=", переносит правую часть влево: "left - (right)"
-// 2. Добавляет поддержку математических функций (ln, log, sin и др.)
-func ParseFormula(formula string) (*govaluate.EvaluableExpression, error) {
-	// Подготавливаем строку: если уравнение имеет вид A = B, преобразуем в A - (B)
-	parts := strings.Split(formula, "=")
-	var exprStr string
-	if len(parts) == 2 {
-		left := strings.TrimSpace(parts[0])
-		right := strings.TrimSpace(parts[1])
-		if right == "0" {
-			exprStr = left
-		} else {
-			exprStr = fmt.Sprintf("%s - (%s)", left, right)
+// infixBP возвращает силу связывания бинарного оператора
+func infixBP(op string) int {
+	switch op {
+	case "+", "-":
+		return bpAdd
+	case "*", "/":
+		return bpMul
+	case "^":
+		return bpPow
+	}
+	return bpNone
+}
+
+// ParseTree разбирает формулу в дерево выражения.
+// Уравнение вида "A = B" приводится к виду "A - (B)".
+func ParseTree(formula string) (Node, error) {
+	tokens, err := tokenize(formula)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{src: formula, tokens: tokens}
+	left, err := p.parseExpr(bpNone)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind == tokEquals {
+		p.next()
+		right, err := p.parseExpr(bpNone)
+		if err != nil {
+			return nil, err
+		}
+		if c, ok := right.(*Num); !ok || c.Value != 0 {
+			left = &Binary{Op: '-', L: left, R: right}
 		}
-	} else if len(parts) == 1 {
-		exprStr = strings.TrimSpace(parts[0])
-	} else {
-		return nil, fmt.Errorf("формула содержит больше одного знака '='")
 	}
 
-	// Заменяем знак степени '^' на понятный библиотеке `govaluate` знак '**'
-	exprStr = strings.ReplaceAll(exprStr, "^", "**")
-
-	// Оборачиваем аргументы функций без скобок в скобки: `ln x` -> `ln(x)`
-	reFuncParens := regexp.MustCompile(`(ln|log|sin|cos|tan|sqrt|abs|exp)\s+([a-zA-Z0-9_\.]+)`)
-	exprStr = reFuncParens.ReplaceAllString(exprStr, "$1($2)")
-
-	// Окружение для добавления кастомных функций
-	functions := map[string]govaluate.ExpressionFunction{
-		"ln": func(args ...interface{}) (interface{}, error) {
-			x := args[0].(float64)
-			return math.Log(x), nil
-		},
-		"log": func(args ...interface{}) (interface{}, error) {
-			x := args[0].(float64)
-			return math.Log10(x), nil
-		},
-		"sin": func(args ...interface{}) (interface{}, error) {
-			x := args[0].(float64)
-			return math.Sin(x), nil
-		},
-		"cos": func(args ...interface{}) (interface{}, error) {
-			x := args[0].(float64)
-			return math.Cos(x), nil
-		},
-		"tan": func(args ...interface{}) (interface{}, error) {
-			x := args[0].(float64)
-			return math.Tan(x), nil
-		},
-		"sqrt": func(args ...interface{}) (interface{}, error) {
-			x := args[0].(float64)
-			return math.Sqrt(x), nil
-		},
-		"abs": func(args ...interface{}) (interface{}, error) {
-			x := args[0].(float64)
-			return math.Abs(x), nil
-		},
-		"exp": func(args ...interface{}) (interface{}, error) {
-			x := args[0].(float64)
-			return math.Exp(x), nil
-		},
+	if t := p.peek(); t.kind != tokEOF {
+		if t.kind == tokEquals {
+			return nil, p.errorAt(t, "формула содержит больше одного знака '='")
+		}
+		return nil, p.errorAt(t, fmt.Sprintf("неожиданная лексема %q", t.text))
 	}
 
-	fn, err := govaluate.NewEvaluableExpressionWithFunctions(exprStr, functions)
+	return left, nil
+}
+
+// parser - разбор выражения методом Пратта (top down operator precedence)
+type parser struct {
+	src    string
+	tokens []token
+	i      int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) errorAt(t token, msg string) error {
+	return &SyntaxError{Source: p.src, Pos: t.pos, Msg: msg}
+}
+
+// parseExpr разбирает выражение, в котором все бинарные операторы связывают
+// сильнее, чем minBP
+func (p *parser) parseExpr(minBP int) (Node, error) {
+	left, err := p.parsePrefix()
 	if err != nil {
-		return nil, fmt.Errorf("ошибка парсинга формулы '%s': %w", exprStr, err)
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+
+		op := t.text
+		switch {
+		case t.kind == tokOp:
+		case t.kind == tokIdent || t.kind == tokLParen:
+			// Неявное умножение: операнд сразу за операндом - 2x, 3sin(x), x(x-1), (x+1)(x-1).
+			// Число справа не допускается, чтобы "2 3" или "x 2" оставались ошибкой.
+			op = "*"
+		default:
+			return left, nil
+		}
+
+		bp := infixBP(op)
+		if bp <= minBP {
+			return left, nil
+		}
+		if t.kind == tokOp {
+			p.next()
+		}
+
+		// Степень правоассоциативна: x^2^3 = x^(2^3)
+		rightBP := bp
+		if op == "^" {
+			rightBP = bp - 1
+		}
+
+		right, err := p.parseExpr(rightBP)
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: rune(op[0]), L: left, R: right}
+	}
+}
+
+// parsePrefix разбирает операнд: число, переменную, вызов функции,
+// выражение в скобках или операнд с унарным знаком
+func (p *parser) parsePrefix() (Node, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokNumber:
+		return &Num{Value: t.num}, nil
+
+	case tokIdent:
+		if isFunction(t.text) {
+			return p.parseCall(t)
+		}
+		return &Var{Name: t.text, Pos: t.pos}, nil
+
+	case tokLParen:
+		node, err := p.parseExpr(bpNone)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, p.errorAt(closing, "ожидалась закрывающая скобка ')'")
+		}
+		return node, nil
+
+	case tokOp:
+		switch t.text {
+		case "-":
+			// Унарный минус слабее степени: -x^2 = -(x^2), e^-x = e^(-x)
+			x, err := p.parseExpr(bpPrefix)
+			if err != nil {
+				return nil, err
+			}
+			return &Unary{Op: '-', X: x}, nil
+		case "+":
+			return p.parseExpr(bpPrefix)
+		}
+
+	case tokEOF:
+		return nil, p.errorAt(t, "ожидалось выражение")
+	}
+
+	return nil, p.errorAt(t, fmt.Sprintf("неожиданная лексема %q", t.text))
+}
+
+// parseCall разбирает вызов функции: со скобками "sin(x)" или без них "ln x"
+func (p *parser) parseCall(name token) (Node, error) {
+	if p.peek().kind != tokLParen {
+		arg, err := p.parseExpr(bpArg)
+		if err != nil {
+			return nil, err
+		}
+		return &Call{Name: name.text, Args: []Node{arg}, Pos: name.pos}, nil
+	}
+	p.next()
+
+	var args []Node
+	if p.peek().kind != tokRParen {
+		for {
+			arg, err := p.parseExpr(bpNone)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			if p.peek().kind != tokComma {
+				break
+			}
+			p.next()
+		}
+	}
+
+	if closing := p.next(); closing.kind != tokRParen {
+		return nil, p.errorAt(closing, "ожидалась закрывающая скобка ')' после аргументов функции")
+	}
+
+	if err := checkArity(name.text, len(args)); err != nil {
+		return nil, p.errorAt(name, err.Error())
 	}
 
-	return fn, nil
+	return &Call{Name: name.text, Args: args, Pos: name.pos}, nil
 }
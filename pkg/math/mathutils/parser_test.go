@@ -0,0 +1,116 @@
+package mathutils
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// sexpr записывает дерево в префиксной форме со всеми скобками: (+ 1 (* 2 x)).
+// По ней видно, как парсер расставил приоритеты, независимо от того,
+// как String опускает скобки.
+func sexpr(n Node) string {
+	switch n := n.(type) {
+	case *Num:
+		return strconv.FormatFloat(n.Value, 'g', -1, 64)
+	case *Var:
+		return n.Name
+	case *Unary:
+		return "(" + string(n.Op) + " " + sexpr(n.X) + ")"
+	case *Binary:
+		return "(" + string(n.Op) + " " + sexpr(n.L) + " " + sexpr(n.R) + ")"
+	case *Call:
+		args := make([]string, len(n.Args))
+		for i, arg := range n.Args {
+			args[i] = sexpr(arg)
+		}
+		return "(" + n.Name + " " + strings.Join(args, " ") + ")"
+	}
+	return "?"
+}
+
+func TestParsePrecedence(t *testing.T) {
+	tests := []struct {
+		formula string
+		want    string
+	}{
+		{"1 + 2*3", "(+ 1 (* 2 3))"},
+		{"(1 + 2)*3", "(* (+ 1 2) 3)"},
+		{"1 - 2 - 3", "(- (- 1 2) 3)"},
+		{"8 / 4 / 2", "(/ (/ 8 4) 2)"},
+		{"2^3^2", "(^ 2 (^ 3 2))"},
+		{"2**3", "(^ 2 3)"},
+		{"-x^2", "(- (^ x 2))"},
+		{"-x*y", "(* (- x) y)"},
+		{"e^-x", "(^ e (- x))"},
+		{"2^-x^2", "(^ 2 (- (^ x 2)))"},
+		{"+x - 1", "(- x 1)"},
+		{"sin(x)^2", "(^ (sin x) 2)"},
+		{"ln x + 1", "(+ (ln x) 1)"},
+		{"ln x^2", "(^ (ln x) 2)"}, // аргумент без скобок связывает сильнее степени
+		{"pow(x, 2) + 1", "(+ (pow x 2) 1)"},
+		{"x^2 = 4", "(- (^ x 2) 4)"},
+		{"x^2 - 4 = 0", "(- (^ x 2) 4)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			tree, err := ParseTree(tt.formula)
+			if err != nil {
+				t.Fatalf("ParseTree: %v", err)
+			}
+			if got := sexpr(tree); got != tt.want {
+				t.Errorf("ParseTree(%q) = %s, ожидалось %s", tt.formula, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseImplicitMultiplication(t *testing.T) {
+	tests := []struct {
+		formula string
+		want    string
+	}{
+		{"2x", "(* 2 x)"},
+		{"2x^2", "(* 2 (^ x 2))"},
+		{"3sin(x)", "(* 3 (sin x))"},
+		{"x(x - 1)", "(* x (- x 1))"},
+		{"(x + 1)(x - 1)", "(* (+ x 1) (- x 1))"},
+		{"2 pi x", "(* (* 2 pi) x)"},
+		{"1/2x", "(* (/ 1 2) x)"},
+		{"-2x", "(* (- 2) x)"}, // унарный минус сильнее умножения
+		{"2x + 3y", "(+ (* 2 x) (* 3 y))"},
+		{"1e-3x", "(* 0.001 x)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			tree, err := ParseTree(tt.formula)
+			if err != nil {
+				t.Fatalf("ParseTree: %v", err)
+			}
+			if got := sexpr(tree); got != tt.want {
+				t.Errorf("ParseTree(%q) = %s, ожидалось %s", tt.formula, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"2 3",
+		"x 2",
+		"(x + 1",
+		"x + 1)",
+		"x = 1 = 2",
+		"2 *",
+		"x²",
+		"1..2",
+	}
+	for _, formula := range tests {
+		t.Run(formula, func(t *testing.T) {
+			if tree, err := ParseTree(formula); err == nil {
+				t.Errorf("ParseTree(%q) = %s, ожидалась ошибка", formula, sexpr(tree))
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package mathutils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNotPolynomial возвращается, если выражение не является многочленом от переменной
+var ErrNotPolynomial = errors.New("формула не является многочленом")
+
+// MaxPolynomialDegree - наибольшая степень многочлена, коэффициенты которого извлекаются
+const MaxPolynomialDegree = 100
+
+// Polynomial раскладывает выражение в многочлен от переменной v и возвращает
+// коэффициенты в порядке возрастания степеней: a0 + a1*v + ... + an*v^n.
+// Подвыражения без v (в том числе с параметрами и функциями) вычисляются как числа.
+// Дерево должно быть предварительно проверено Compile, иначе ошибки имен
+// будут указывать на позиции в подвыражении, а не в исходной формуле.
+func Polynomial(n Node, v string, params map[string]float64) ([]float64, error) {
+	p, err := polynomial(n, v, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Старшие коэффициенты могут сократиться: x^2 - x^2 + x
+	for len(p) > 1 && p[len(p)-1] == 0 {
+		p = p[:len(p)-1]
+	}
+	return p, nil
+}
+
+func polynomial(n Node, v string, params map[string]float64) ([]float64, error) {
+	if !dependsOn(n, v) {
+		prog, err := Compile(n, n.String(), params)
+		if err != nil {
+			return nil, err
+		}
+		return []float64{prog.Eval(nil)}, nil
+	}
+
+	switch n := n.(type) {
+	case *Var:
+		return []float64{0, 1}, nil
+	case *Unary:
+		x, err := polynomial(n.X, v, params)
+		if err != nil {
+			return nil, err
+		}
+		return polyScale(x, -1), nil
+	case *Binary:
+		return polynomialBinary(n.Op, n.L, n.R, v, params)
+	case *Call:
+		if n.Name == "pow" && len(n.Args) == 2 {
+			return polynomialBinary('^', n.Args[0], n.Args[1], v, params)
+		}
+		return nil, fmt.Errorf("%w: %s зависит от %s", ErrNotPolynomial, n.String(), v)
+	}
+	return nil, fmt.Errorf("%w: неизвестный узел %T", ErrNotPolynomial, n)
+}
+
+func polynomialBinary(op rune, l, r Node, v string, params map[string]float64) ([]float64, error) {
+	a, err := polynomial(l, v, params)
+	if err != nil {
+		return nil, err
+	}
+	b, err := polynomial(r, v, params)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case '+':
+		return polyAdd(a, b, 1), nil
+	case '-':
+		return polyAdd(a, b, -1), nil
+	case '*':
+		return polyMul(a, b)
+	case '/':
+		if dependsOn(r, v) {
+			return nil, fmt.Errorf("%w: деление на выражение с %s", ErrNotPolynomial, v)
+		}
+		return polyScale(a, 1/b[0]), nil
+	case '^':
+		k := b[0]
+		if dependsOn(r, v) || k < 0 || k != math.Trunc(k) {
+			return nil, fmt.Errorf("%w: показатель степени должен быть целым неотрицательным числом", ErrNotPolynomial)
+		}
+		if len(a) == 1 {
+			return []float64{math.Pow(a[0], k)}, nil
+		}
+		if k > MaxPolynomialDegree || k*float64(len(a)-1) > MaxPolynomialDegree {
+			return nil, fmt.Errorf("степень многочлена больше %d", MaxPolynomialDegree)
+		}
+		return polyPow(a, int(k))
+	}
+	return nil, fmt.Errorf("%w: неизвестная операция %q", ErrNotPolynomial, op)
+}
+
+// polyPow возводит a в степень k быстрым возведением (square-and-multiply)
+func polyPow(a []float64, k int) ([]float64, error) {
+	res := []float64{1}
+	var err error
+	for ; k > 0; k >>= 1 {
+		if k&1 == 1 {
+			if res, err = polyMul(res, a); err != nil {
+				return nil, err
+			}
+		}
+		if k > 1 {
+			if a, err = polyMul(a, a); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// polyAdd возвращает a + sign*b
+func polyAdd(a, b []float64, sign float64) []float64 {
+	res := make([]float64, max(len(a), len(b)))
+	copy(res, a)
+	for i, c := range b {
+		res[i] += sign * c
+	}
+	return res
+}
+
+func polyScale(a []float64, k float64) []float64 {
+	res := make([]float64, len(a))
+	for i, c := range a {
+		res[i] = k * c
+	}
+	return res
+}
+
+func polyMul(a, b []float64) ([]float64, error) {
+	if len(a)+len(b)-2 > MaxPolynomialDegree {
+		return nil, fmt.Errorf("степень многочлена больше %d", MaxPolynomialDegree)
+	}
+
+	res := make([]float64, len(a)+len(b)-1)
+	for i, x := range a {
+		for j, y := range b {
+			res[i+j] += x * y
+		}
+	}
+	return res, nil
+}
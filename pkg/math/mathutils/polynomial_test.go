@@ -0,0 +1,97 @@
+package mathutils
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func polynomialOf(t *testing.T, formula string, params map[string]float64) ([]float64, error) {
+	t.Helper()
+	tree, err := ParseTree(formula)
+	if err != nil {
+		t.Fatalf("ParseTree(%q): %v", formula, err)
+	}
+	return Polynomial(tree, "x", params)
+}
+
+func TestPolynomial(t *testing.T) {
+	tests := []struct {
+		formula string
+		params  map[string]float64
+		want    []float64
+	}{
+		{"5", nil, []float64{5}},
+		{"x", nil, []float64{0, 1}},
+		{"x^2 - 3x + 2", nil, []float64{2, -3, 1}},
+		{"(x + 1)^3", nil, []float64{1, 3, 3, 1}},
+		{"(x - 1)(x + 1)", nil, []float64{-1, 0, 1}},
+		{"x(x - 1) = 6", nil, []float64{-6, -1, 1}},
+		{"a*x^2 + x/2", map[string]float64{"a": 2}, []float64{0, 0.5, 2}},
+		{"-(x^2) + sin(0)*x", nil, []float64{0, 0, -1}},
+		{"pow(x, 2) + 2^3", nil, []float64{8, 0, 1}},
+		{"x^2 - x^2 + x", nil, []float64{0, 1}},
+		{"(2x)^10", nil, []float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1024}},
+		// Постоянное основание возводится в степень сразу, без k умножений
+		{"x + (x^0)^100000000", nil, []float64{1, 1}},
+		{"x + 2^0.5", nil, []float64{math.Sqrt2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			got, err := polynomialOf(t, tt.formula, tt.params)
+			if err != nil {
+				t.Fatalf("Polynomial: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Polynomial(%q) = %v, ожидалось %v", tt.formula, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolynomialHighDegree(t *testing.T) {
+	// Коэффициенты (x + 1)^n - биномиальные, сумма равна 2^n
+	got, err := polynomialOf(t, "(x + 1)^40", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 41 {
+		t.Fatalf("степень = %d, ожидалось 40", len(got)-1)
+	}
+	sum := 0.0
+	for _, c := range got {
+		sum += c
+	}
+	if sum != math.Pow(2, 40) {
+		t.Errorf("сумма коэффициентов = %v, ожидалось 2^40", sum)
+	}
+}
+
+func TestPolynomialErrors(t *testing.T) {
+	tests := []struct {
+		formula       string
+		notPolynomial bool // Ожидается ErrNotPolynomial, иначе - превышение степени
+	}{
+		{"sin(x)", true},
+		{"1/x", true},
+		{"x^0.5", true},
+		{"x^-1", true},
+		{"2^x", true},
+		{"x^x", true},
+		{"x^101", false},
+		{"(x^2 + 1)^51", false},
+		{"(x + 1)^100000000", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.formula, func(t *testing.T) {
+			_, err := polynomialOf(t, tt.formula, nil)
+			if err == nil {
+				t.Fatal("ожидалась ошибка")
+			}
+			if errors.Is(err, ErrNotPolynomial) != tt.notPolynomial {
+				t.Errorf("ошибка = %v, errors.Is(ErrNotPolynomial) = %v", err, !tt.notPolynomial)
+			}
+		})
+	}
+}
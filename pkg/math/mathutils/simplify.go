@@ -0,0 +1,192 @@
+package mathutils
+
+import (
+	"math"
+	"strconv"
+)
+
+// Simplify упрощает выражение: сворачивает константы и убирает
+// тривиальные операции вроде x*1, x+0, x^1. Результат эквивалентен исходному.
+func Simplify(n Node) Node {
+	// Правила применяются снизу вверх, повторяем, пока запись не перестанет меняться
+	prev := n.String()
+	for range 10 {
+		n = simplify(n)
+		cur := n.String()
+		if cur == prev {
+			break
+		}
+		prev = cur
+	}
+	return n
+}
+
+// isNum проверяет, что узел - числовая константа с заданным значением
+func isNum(n Node, v float64) bool {
+	c, ok := n.(*Num)
+	return ok && c.Value == v
+}
+
+func simplify(n Node) Node {
+	switch n := n.(type) {
+	case *Unary:
+		x := simplify(n.X)
+		switch x := x.(type) {
+		case *Num:
+			return num(-x.Value)
+		case *Unary:
+			// -(-u) = u
+			return x.X
+		}
+		return neg(x)
+
+	case *Call:
+		args := make([]Node, len(n.Args))
+		for i, a := range n.Args {
+			args[i] = simplify(a)
+		}
+		return &Call{Name: n.Name, Args: args}
+
+	case *Binary:
+		return simplifyBinary(n.Op, simplify(n.L), simplify(n.R))
+	}
+
+	return n
+}
+
+func simplifyBinary(op rune, l, r Node) Node {
+	lc, lConst := l.(*Num)
+	rc, rConst := r.(*Num)
+
+	// Обе части - числа: сворачиваем константу
+	if lConst && rConst {
+		if v, ok := fold(op, lc.Value, rc.Value); ok {
+			return num(v)
+		}
+	}
+
+	switch op {
+	case '+':
+		if isNum(l, 0) {
+			return r
+		}
+		if isNum(r, 0) {
+			return l
+		}
+		if u, ok := r.(*Unary); ok {
+			return sub(l, u.X)
+		}
+		if rConst && rc.Value < 0 {
+			return sub(l, num(-rc.Value))
+		}
+	case '-':
+		if isNum(r, 0) {
+			return l
+		}
+		if isNum(l, 0) {
+			return neg(r)
+		}
+		if u, ok := r.(*Unary); ok {
+			return add(l, u.X)
+		}
+		if l.String() == r.String() {
+			return num(0)
+		}
+		if rConst && rc.Value < 0 {
+			return add(l, num(-rc.Value))
+		}
+	case '*':
+		if isNum(l, 0) || isNum(r, 0) {
+			return num(0)
+		}
+		if isNum(l, 1) {
+			return r
+		}
+		if isNum(r, 1) {
+			return l
+		}
+		if isNum(l, -1) {
+			return neg(r)
+		}
+		if isNum(r, -1) {
+			return neg(l)
+		}
+		// Выносим минус вперед: (-u)*w = -(u*w)
+		if u, ok := l.(*Unary); ok {
+			return neg(mul(u.X, r))
+		}
+		if u, ok := r.(*Unary); ok {
+			return neg(mul(l, u.X))
+		}
+		// Деление на множитель пишем дробью: (1/u)*w = w/u
+		if q, ok := l.(*Binary); ok && q.Op == '/' && isNum(q.L, 1) {
+			return div(r, q.R)
+		}
+		if q, ok := r.(*Binary); ok && q.Op == '/' && isNum(q.L, 1) {
+			return div(l, q.R)
+		}
+		// Числовой множитель пишем первым: u*2 = 2*u
+		if rConst && !lConst {
+			return simplifyBinary('*', r, l)
+		}
+		// Объединяем числовые множители: 2*(3*u) = 6*u
+		if lConst {
+			if inner, ok := r.(*Binary); ok && inner.Op == '*' {
+				if ic, ok := inner.L.(*Num); ok {
+					return mul(num(lc.Value*ic.Value), inner.R)
+				}
+			}
+		}
+	case '/':
+		if isNum(l, 0) {
+			return num(0)
+		}
+		if isNum(r, 1) {
+			return l
+		}
+		if u, ok := l.(*Unary); ok {
+			return neg(div(u.X, r))
+		}
+		if l.String() == r.String() {
+			return num(1)
+		}
+	case '^':
+		if isNum(r, 0) {
+			return num(1)
+		}
+		if isNum(r, 1) {
+			return l
+		}
+		if isNum(l, 1) {
+			return num(1)
+		}
+	}
+
+	return &Binary{Op: op, L: l, R: r}
+}
+
+// fold вычисляет операцию над двумя числами. Результаты вроде деления на ноль
+// не сворачиваются, чтобы не потерять исходную запись. Деление сворачивается,
+// только если результат записывается коротко: 1/3 нагляднее, чем 0.3333333333333333.
+func fold(op rune, a, b float64) (float64, bool) {
+	var v float64
+	switch op {
+	case '+':
+		v = a + b
+	case '-':
+		v = a - b
+	case '*':
+		v = a * b
+	case '/':
+		v = a / b
+		if len(strconv.FormatFloat(v, 'f', -1, 64)) > 8 {
+			return 0, false
+		}
+	case '^':
+		v = math.Pow(a, b)
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, false
+	}
+	return v, true
+}
@@ -1,23 +1,27 @@
 package math
 
 import (
+	"context"
 	"fmt"
 	"math"
-
-	"github.com/GeorgeTyupin/numerical_methods/pkg/math/mathutils"
-	"github.com/Knetic/govaluate"
-	"gonum.org/v1/gonum/diff/fd"
 )
 
-type NewtonStep struct {
-	XPrev float64
-	XNew  float64
-	Fx    float64
+func init() {
+	Register(Method{
+		Name:   "newton",
+		Title:  "Метод Ньютона (касательных)",
+		Params: []ParamSpec{formulaParam, paramsParam, epsilonParam, x0Param},
+		New: func(p Params) (Solver, error) {
+			return NewNewtonMethodCalculator(p.String("formula"), p.Values("params"), p.Float("x0"), p.Float("epsilon"))
+		},
+	})
 }
 
 type NewtonMethodCalculator struct {
+	stopper
+
 	// Функция f(x), которую мы решаем
-	Func *govaluate.EvaluableExpression
+	Func func(float64) float64
 
 	// Начальное приближение x0
 	X0 float64
@@ -25,66 +29,71 @@ type NewtonMethodCalculator struct {
 	// Требуемая точность (epsilon)
 	Epsilon float64
 
-	// Ограничитель итераций, чтобы сервер не зависал, если корень не сходится
-	MaxIter int
+	// Производная f'(x)
+	derivs derivatives
 }
 
 // NewNewtonMethodCalculator создает новый экземпляр NewtonMethodCalculator
 // reqFunc - функция в виде строки, например "x^3 - 2*x - 5"
+// params - значения именованных параметров формулы, например {"a": 2}
 // x0 - начальное приближение
 // epsilon - требуемая точность
-func NewNewtonMethodCalculator(reqFunc string, x0 float64, epsilon float64) (*NewtonMethodCalculator, error) {
-	fn, err := mathutils.ParseFormula(reqFunc)
+func NewNewtonMethodCalculator(reqFunc string, params map[string]float64, x0 float64, epsilon float64) (*NewtonMethodCalculator, error) {
+	fn, err := compile(reqFunc, params)
 	if err != nil {
 		return nil, err
 	}
 
 	return &NewtonMethodCalculator{
 		Func:    fn,
+		derivs:  newDerivatives(reqFunc, params, fn),
 		X0:      x0,
 		Epsilon: epsilon,
 	}, nil
 }
 
-// eval вычисляет значение функции в точке x
-func (c *NewtonMethodCalculator) eval(x float64) float64 {
-	res, _ := c.Func.Evaluate(map[string]interface{}{"x": x, "pi": math.Pi, "e": math.E})
-	val, ok := res.(float64)
-	if !ok {
-		return math.NaN()
-	}
-	return val
-}
-
 // Calculate возвращает шаги алгоритма, корень, количество итераций и ошибку
-func (c *NewtonMethodCalculator) Calculate() ([]NewtonStep, float64, int, error) {
-	var steps []NewtonStep
+func (c *NewtonMethodCalculator) Calculate(ctx context.Context) (Result, error) {
+	mt := newMeter(ctx)
+	f := mt.wrap(c.Func)
+	derivs := c.derivs.metered(mt)
+
+	res := Result{Info: c.derivs.info(false)}
 	x := c.X0
 
 	// Цикл для вычисления корня
-	for i := 1; i <= maxIter; i++ {
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
 		// Вычисляем значение функции в точке x
-		fx := c.eval(x)
-		if math.IsNaN(fx) || math.IsInf(fx, 0) {
-			return steps, 0, i, fmt.Errorf("ошибка вычисления функции в точке x=%v", x)
+		fx := f(x)
+		if isBad(fx) {
+			return res, fmt.Errorf("ошибка вычисления функции в точке x=%v", x)
 		}
 
-		// Вычисляем производную численно в точке x
-		dfx := fd.Derivative(c.eval, x, &fd.Settings{Formula: fd.Central})
+		// Вычисляем производную в точке x (символьно, если удалось построить формулу)
+		dfx := derivs.first(x)
 		// Проверка на ноль. Сверяем с 1e-10, потому что в float64 могут быть погрешности
 		if math.Abs(dfx) < 1e-10 {
-			return steps, 0, i, fmt.Errorf("производная равна нулю в точке x=%v", x)
+			return res, fmt.Errorf("производная равна нулю в точке x=%v", x)
 		}
 
 		xNew := x - fx/dfx
-		steps = append(steps, NewtonStep{XPrev: x, XNew: xNew, Fx: fx})
+		res.Steps = record(mt, res.Steps, Step{XPrev: x, XNew: xNew, Fx: fx})
 
 		// Проверка на точность
-		if math.Abs(xNew-x) < c.Epsilon {
-			return steps, xNew, i, nil
+		step := math.Abs(xNew - x)
+		if reason := c.Stop.done(step < c.Epsilon, StopStep, step, xNew, f); reason != "" {
+			res.Root, res.StopReason = xNew, reason
+			return res, nil
 		}
 		x = xNew
 	}
 
-	return steps, x, maxIter, fmt.Errorf("превышено максимальное количество итераций")
+	res.Root = x
+	res.StopReason = StopMaxIter
+	return res, fmt.Errorf("превышено максимальное количество итераций")
 }
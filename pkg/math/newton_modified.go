@@ -0,0 +1,131 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+func init() {
+	Register(Method{
+		Name:   "newton_modified",
+		Title:  "Модифицированный метод Ньютона (кратные корни)",
+		Params: []ParamSpec{formulaParam, paramsParam, epsilonParam, x0Param},
+		New: func(p Params) (Solver, error) {
+			return NewModifiedNewtonMethodCalculator(p.String("formula"), p.Values("params"), p.Float("x0"), p.Float("epsilon"))
+		},
+	})
+}
+
+// ModifiedNewtonMethodCalculator реализует метод Ньютона для кратных корней:
+// x_n+1 = x_n - m f/f', где кратность m оценивается на каждом шаге.
+//
+// Вблизи корня кратности m выполняется первое соотношение, откуда следует оценка m:
+//
+//	f f'' / f'^2 -> (m-1)/m
+//	m ≈ 1 / (1 - f f'' / f'^2)
+//
+// Вдали от корня оценка ненадежна (например, для e^x - 1 она растет как e^x),
+// поэтому кратность m > 1 используется, только если округленная оценка не
+// превышает maxMultiplicity и совпала на двух итерациях подряд; иначе
+// выполняется обычный шаг Ньютона.
+type ModifiedNewtonMethodCalculator struct {
+	stopper
+
+	Func    func(float64) float64
+	X0      float64
+	Epsilon float64
+
+	// Производные f'(x) и f''(x)
+	derivs derivatives
+}
+
+func NewModifiedNewtonMethodCalculator(funcStr string, params map[string]float64, x0, epsilon float64) (*ModifiedNewtonMethodCalculator, error) {
+	fn, err := compile(funcStr, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ModifiedNewtonMethodCalculator{
+		Func:    fn,
+		derivs:  newDerivatives(funcStr, params, fn),
+		X0:      x0,
+		Epsilon: epsilon,
+	}, nil
+}
+
+func (c *ModifiedNewtonMethodCalculator) Calculate(ctx context.Context) (Result, error) {
+	mt := newMeter(ctx)
+	f := mt.wrap(c.Func)
+	derivs := c.derivs.metered(mt)
+
+	res := Result{Info: c.derivs.info(true)}
+	x := c.X0
+
+	// Используемая кратность, ее последняя "сырая" оценка и число итераций
+	// подряд, на которых округленная оценка равна candidate
+	m := 1
+	estimate := 1.0
+	candidate, stable := 1, 0
+	finish := func(root float64, reason string) (Result, error) {
+		res.Root, res.StopReason = root, reason
+		res.Info["multiplicity"] = m
+		res.Info["multiplicity_estimate"] = estimate
+		return res, nil
+	}
+
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		fx := f(x)
+		if isBad(fx) {
+			return res, fmt.Errorf("ошибка вычисления функции в точке x=%v", x)
+		}
+		if fx == 0 {
+			return finish(x, StopExact)
+		}
+
+		d1 := derivs.first(x)
+		if math.Abs(d1) < 1e-10 {
+			// У кратного корня производная обращается в ноль вместе с функцией,
+			// поэтому малая невязка означает, что корень уже найден
+			if math.Abs(fx) < c.Epsilon {
+				return finish(x, StopResidual)
+			}
+			return res, fmt.Errorf("производная равна нулю в точке x=%v", x)
+		}
+		d2 := derivs.second(x)
+
+		ratio := fx * d2 / (d1 * d1)
+		if mEst := 1 / (1 - ratio); !isBad(mEst) && mEst >= 0.5 && mEst < maxMultiplicity+0.5 {
+			estimate = mEst
+			if r := int(math.Round(mEst)); r == candidate {
+				stable++
+			} else {
+				candidate, stable = r, 1
+			}
+		} else {
+			candidate, stable = 1, 0
+		}
+		m = 1
+		if stable >= 2 {
+			m = candidate
+		}
+
+		xNew := x - float64(m)*fx/d1
+		res.Steps = record(mt, res.Steps, Step{XPrev: x, XNew: xNew, Fx: fx})
+
+		step := math.Abs(xNew - x)
+		if reason := c.Stop.done(step < c.Epsilon, StopStep, step, xNew, f); reason != "" {
+			return finish(xNew, reason)
+		}
+		x = xNew
+	}
+
+	res.Root = x
+	res.StopReason = StopMaxIter
+	return res, fmt.Errorf("превышено максимальное количество итераций")
+}
@@ -0,0 +1,146 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/mathutils"
+)
+
+// ODEStep - узел таблицы численного решения задачи Коши
+type ODEStep struct {
+	X     float64
+	Y     float64 // Численное решение y_i
+	F     float64 // f(x_i, y_i)
+	Exact float64 // Точное решение y(x_i), NaN, если оно не задано
+	Error float64 // Глобальная ошибка |y(x_i) - y_i|, NaN, если точное решение не задано
+}
+
+// ODEResult - численное решение задачи Коши на сетке узлов
+type ODEResult struct {
+	Steps    []ODEStep
+	MaxError float64 // Наибольшая глобальная ошибка, NaN без точного решения
+	Info     map[string]any
+}
+
+// ODESolver - метод решения задачи Коши y' = f(x, y), y(a) = y0 (задание 2).
+// Прерывается так же, как Solver.
+type ODESolver interface {
+	Calculate(ctx context.Context) (ODEResult, error)
+}
+
+// ODEMethod - описание метода решения задачи Коши: имя, схема параметров и фабрика
+type ODEMethod struct {
+	Name   string
+	Title  string
+	Params []ParamSpec
+
+	// New создает экземпляр метода по уже проверенным параметрам
+	New func(p Params) (ODESolver, error)
+}
+
+var odeMethods = newRegistry[ODEMethod]("метод для ОДУ")
+
+// RegisterODE добавляет метод решения задачи Коши в реестр
+func RegisterODE(m ODEMethod) {
+	if m.Name == "" || m.New == nil {
+		panic("math: RegisterODE вызван с пустым именем или фабрикой")
+	}
+	odeMethods.add(m.Name, m)
+}
+
+// ODEMethods возвращает все методы решения задачи Коши, отсортированные по имени
+func ODEMethods() []ODEMethod {
+	return odeMethods.all()
+}
+
+// NewODESolver находит метод решения задачи Коши по имени, проверяет параметры и создает решатель
+func NewODESolver(name string, p Params) (ODESolver, error) {
+	m, ok := odeMethods.lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMethod, name)
+	}
+
+	checked, err := p.validate(m.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.New(checked)
+}
+
+// Параметры методов решения задачи Коши
+var (
+	odeFormulaParam = ParamSpec{Name: "formula", Type: ParamFormula, Required: true, Description: "Правая часть f(x, y) уравнения y' = f(x, y), например \"x + y\""}
+	y0Param         = ParamSpec{Name: "y0", Type: ParamNumber, Required: true, Description: "Начальное условие y(a)"}
+	hParam          = ParamSpec{Name: "h", Type: ParamNumber, Required: true, Description: "Шаг сетки; (b - a) / h должно быть целым"}
+	exactParam      = ParamSpec{Name: "exact", Type: ParamFormula, Description: "Точное решение y(x) для вычисления глобальной ошибки, например \"2*exp(x) - x - 1\""}
+	odeParams       = []ParamSpec{odeFormulaParam, paramsParam, aParam, bParam, y0Param, hParam, exactParam}
+)
+
+// ODEProblem - задача Коши y' = f(x, y), y(a) = y0 на сетке x_i = a + ih, i = 0..N
+type ODEProblem struct {
+	F     func(x, y float64) float64
+	Exact func(x float64) float64 // nil, если точное решение не задано
+
+	A, Y0, H float64
+	N        int
+}
+
+// newODEProblem компилирует правую часть и точное решение и строит сетку
+func newODEProblem(p Params) (ODEProblem, error) {
+	var pr ODEProblem
+
+	params := p.Values("params")
+	prog, err := mathutils.ParseFormulaXY(p.String("formula"), params)
+	if err != nil {
+		return pr, err
+	}
+	pr.F = prog.Eval2
+
+	if p.Has("exact") {
+		if pr.Exact, err = compile(p.String("exact"), params); err != nil {
+			return pr, fmt.Errorf("точное решение: %w", err)
+		}
+	}
+
+	pr.A, pr.Y0, pr.H = p.Float("a"), p.Float("y0"), p.Float("h")
+	b := p.Float("b")
+	if !(b > pr.A) {
+		return pr, fmt.Errorf("правая граница b должна быть больше левой a")
+	}
+	if !(pr.H > 0) {
+		return pr, fmt.Errorf("шаг h должен быть положительным")
+	}
+
+	// Многошаговым методам нужна равномерная сетка, поэтому шаг должен
+	// укладываться в отрезок целое число раз
+	steps := (b - pr.A) / pr.H
+	n := math.Round(steps)
+	if n < 1 || math.Abs(steps-n) > odeGridTol*n {
+		return pr, fmt.Errorf("шаг h = %v не укладывается в отрезок [%v, %v] целое число раз", pr.H, pr.A, b)
+	}
+	if n > maxODESteps {
+		return pr, fmt.Errorf("число шагов сетки не должно превышать %d", maxODESteps)
+	}
+	pr.N = int(n)
+
+	return pr, nil
+}
+
+// node возвращает i-й узел сетки. Узлы вычисляются от a, а не накоплением
+// шага, чтобы последний узел совпадал с b без ошибки округления.
+func (pr ODEProblem) node(i int) float64 {
+	return pr.A + float64(i)*pr.H
+}
+
+// row строит строку таблицы, вычисляя точное решение и ошибку, если оно задано
+func (pr ODEProblem) row(x, y, f float64) ODEStep {
+	s := ODEStep{X: x, Y: y, F: f, Exact: math.NaN(), Error: math.NaN()}
+	if pr.Exact != nil {
+		s.Exact = pr.Exact(x)
+		s.Error = math.Abs(s.Exact - y)
+	}
+	return s
+}
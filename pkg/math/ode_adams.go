@@ -0,0 +1,43 @@
+package math
+
+func init() {
+	RegisterODE(ODEMethod{
+		Name:   "adams2",
+		Title:  "Метод Адамса - Башфорта 2-го порядка",
+		Params: odeParams,
+		New: func(p Params) (ODESolver, error) {
+			return NewODECalculator(p, odeScheme{order: 2, step: rk4Step, adams: adamsBashforth2})
+		},
+	})
+	RegisterODE(ODEMethod{
+		Name:   "adams4",
+		Title:  "Метод Адамса - Башфорта 4-го порядка",
+		Params: odeParams,
+		New: func(p Params) (ODESolver, error) {
+			return NewODECalculator(p, odeScheme{order: 4, step: rk4Step, adams: adamsBashforth4})
+		},
+	})
+}
+
+// Коэффициенты явных методов Адамса - Башфорта при f_n, f_n-1, ...:
+//
+//	y_n+1 = y_n + h·(3/2·f_n - 1/2·f_n-1),
+//	y_n+1 = y_n + h/24·(55f_n - 59f_n-1 + 37f_n-2 - 9f_n-3).
+//
+// Метод k-го порядка использует k предыдущих значений f и вычисляет f
+// только один раз на шаге, но первые k - 1 значений получаются методом
+// Рунге - Кутты 4-го порядка, чтобы не испортить порядок точности.
+var (
+	adamsBashforth2 = []float64{3.0 / 2, -1.0 / 2}
+	adamsBashforth4 = []float64{55.0 / 24, -59.0 / 24, 37.0 / 24, -9.0 / 24}
+)
+
+// adamsStep выполняет шаг многошагового метода. fs - значения f от самого
+// старого к f_n, coeffs - коэффициенты от f_n к самому старому.
+func adamsStep(y, h float64, fs, coeffs []float64) float64 {
+	s := 0.0
+	for j, b := range coeffs {
+		s += b * fs[len(fs)-1-j]
+	}
+	return y + h*s
+}
@@ -0,0 +1,136 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+func init() {
+	RegisterODE(ODEMethod{
+		Name:   "euler",
+		Title:  "Явный метод Эйлера",
+		Params: odeParams,
+		New: func(p Params) (ODESolver, error) {
+			return NewODECalculator(p, odeScheme{order: 1, step: eulerStep})
+		},
+	})
+	RegisterODE(ODEMethod{
+		Name:   "heun",
+		Title:  "Усовершенствованный метод Эйлера (Хойна)",
+		Params: odeParams,
+		New: func(p Params) (ODESolver, error) {
+			return NewODECalculator(p, odeScheme{order: 2, step: heunStep})
+		},
+	})
+	RegisterODE(ODEMethod{
+		Name:   "rk4",
+		Title:  "Классический метод Рунге - Кутты 4-го порядка",
+		Params: odeParams,
+		New: func(p Params) (ODESolver, error) {
+			return NewODECalculator(p, odeScheme{order: 4, step: rk4Step})
+		},
+	})
+}
+
+// odeStepper выполняет один шаг одношагового метода из (x, y) длины h.
+// fxy = f(x, y) уже вычислено для таблицы и передается, чтобы не считать его повторно.
+type odeStepper func(f func(x, y float64) float64, x, y, fxy, h float64) float64
+
+// odeScheme - расчетная схема метода. Многошаговая схема Адамса - Башфорта
+// задается коэффициентами adams при f_n, f_n-1, ...; недостающие в начале
+// значения вычисляются одношаговым методом step.
+type odeScheme struct {
+	order int
+	step  odeStepper
+	adams []float64
+}
+
+// eulerStep: y_n+1 = y_n + h·f(x_n, y_n)
+func eulerStep(f func(x, y float64) float64, x, y, fxy, h float64) float64 {
+	return y + h*fxy
+}
+
+// heunStep - прогноз методом Эйлера и коррекция по средней из наклонов на концах шага:
+// y_n+1 = y_n + h/2·(f(x_n, y_n) + f(x_n+1, y_n + h·f(x_n, y_n)))
+func heunStep(f func(x, y float64) float64, x, y, fxy, h float64) float64 {
+	predictor := y + h*fxy
+	return y + h/2*(fxy+f(x+h, predictor))
+}
+
+// rk4Step - классический метод Рунге - Кутты:
+// y_n+1 = y_n + h/6·(k1 + 2k2 + 2k3 + k4)
+func rk4Step(f func(x, y float64) float64, x, y, fxy, h float64) float64 {
+	k1 := fxy
+	k2 := f(x+h/2, y+h/2*k1)
+	k3 := f(x+h/2, y+h/2*k2)
+	k4 := f(x+h, y+h*k3)
+	return y + h/6*(k1+2*k2+2*k3+k4)
+}
+
+// ODECalculator решает задачу Коши по заданной схеме и строит таблицу
+// значений во всех узлах сетки. Глобальная ошибка метода порядка p убывает
+// как O(hᵖ).
+type ODECalculator struct {
+	ODEProblem
+
+	scheme odeScheme
+}
+
+func NewODECalculator(p Params, scheme odeScheme) (*ODECalculator, error) {
+	pr, err := newODEProblem(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ODECalculator{ODEProblem: pr, scheme: scheme}, nil
+}
+
+func (c *ODECalculator) Calculate(ctx context.Context) (ODEResult, error) {
+	mt := newMeter(ctx)
+	evaluations := 0
+	f := func(x, y float64) float64 {
+		evaluations++
+		mt.tick()
+		return c.F(x, y)
+	}
+
+	res := ODEResult{MaxError: math.NaN(), Info: map[string]any{"order": c.scheme.order, "h": c.H, "nodes": c.N + 1}}
+	if c.scheme.adams != nil {
+		res.Info["starter"] = "rk4"
+	}
+	defer func() { res.Info["evaluations"] = evaluations }()
+
+	// История f_i нужна многошаговым методам
+	fs := make([]float64, 0, c.N+1)
+	x, y := c.A, c.Y0
+
+	for i := 0; ; i++ {
+		fxy := f(x, y)
+		if isBad(y) || isBad(fxy) {
+			return res, fmt.Errorf("решение не является конечным числом в точке x = %v: уменьшите шаг или проверьте правую часть", x)
+		}
+		fs = append(fs, fxy)
+
+		row := c.row(x, y, fxy)
+		res.Steps = record(mt, res.Steps, row)
+		if math.IsNaN(res.MaxError) || row.Error > res.MaxError {
+			res.MaxError = row.Error
+		}
+		if i == c.N {
+			break
+		}
+
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		if k := len(c.scheme.adams); k > 0 && i >= k-1 {
+			y = adamsStep(y, c.H, fs[i-k+1:], c.scheme.adams)
+		} else {
+			y = c.scheme.step(f, x, y, fxy, c.H)
+		}
+		x = c.node(i + 1)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,205 @@
+package math
+
+import "fmt"
+
+// Params - входные параметры метода в виде "имя -> значение".
+// Числа приходят из JSON как float64, строки и формулы - как string.
+type Params map[string]any
+
+// Has проверяет, что параметр передан
+func (p Params) Has(name string) bool {
+	v, ok := p[name]
+	return ok && v != nil
+}
+
+// Float возвращает числовой параметр. Параметры должны быть предварительно
+// проверены по схеме метода, поэтому отсутствие значения дает 0.
+func (p Params) Float(name string) float64 {
+	v, _ := toFloat(p[name])
+	return v
+}
+
+// String возвращает строковый параметр
+func (p Params) String(name string) string {
+	s, _ := p[name].(string)
+	return s
+}
+
+// Values возвращает параметр-объект "имя -> число"
+func (p Params) Values(name string) map[string]float64 {
+	v, _ := p[name].(map[string]float64)
+	return v
+}
+
+// Vector возвращает параметр-массив чисел
+func (p Params) Vector(name string) []float64 {
+	v, _ := p[name].([]float64)
+	return v
+}
+
+// Matrix возвращает параметр-матрицу
+func (p Params) Matrix(name string) [][]float64 {
+	v, _ := p[name].([][]float64)
+	return v
+}
+
+// Strings возвращает параметр-массив строк (имен или формул)
+func (p Params) Strings(name string) []string {
+	v, _ := p[name].([]string)
+	return v
+}
+
+// validate проверяет параметры по схеме и возвращает копию с подставленными значениями по умолчанию
+func (p Params) validate(specs []ParamSpec) (Params, error) {
+	checked := make(Params, len(specs))
+
+	for _, spec := range specs {
+		v, ok := p[spec.Name]
+		if !ok || v == nil {
+			if spec.Required {
+				return nil, fmt.Errorf("отсутствует обязательный параметр %q", spec.Name)
+			}
+			if spec.Default != nil {
+				checked[spec.Name] = spec.Default
+			}
+			continue
+		}
+
+		switch spec.Type {
+		case ParamNumber:
+			f, ok := toFloat(v)
+			if !ok {
+				return nil, fmt.Errorf("параметр %q должен быть числом", spec.Name)
+			}
+			checked[spec.Name] = f
+		case ParamFormula, ParamString:
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("параметр %q должен быть строкой", spec.Name)
+			}
+			checked[spec.Name] = s
+		case ParamValues:
+			values, err := toValues(v)
+			if err != nil {
+				return nil, fmt.Errorf("параметр %q: %w", spec.Name, err)
+			}
+			checked[spec.Name] = values
+		case ParamVector:
+			vector, err := toVector(v)
+			if err != nil {
+				return nil, fmt.Errorf("параметр %q: %w", spec.Name, err)
+			}
+			checked[spec.Name] = vector
+		case ParamMatrix:
+			matrix, err := toMatrix(v)
+			if err != nil {
+				return nil, fmt.Errorf("параметр %q: %w", spec.Name, err)
+			}
+			checked[spec.Name] = matrix
+		case ParamNames, ParamSystem:
+			strs, err := toStrings(v)
+			if err != nil {
+				return nil, fmt.Errorf("параметр %q: %w", spec.Name, err)
+			}
+			checked[spec.Name] = strs
+		}
+	}
+
+	return checked, nil
+}
+
+// toFloat приводит числовые значения разных типов к float64
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// toValues приводит объект из JSON к виду "имя -> число"
+func toValues(v any) (map[string]float64, error) {
+	switch m := v.(type) {
+	case map[string]float64:
+		return m, nil
+	case map[string]any:
+		values := make(map[string]float64, len(m))
+		for name, raw := range m {
+			f, ok := toFloat(raw)
+			if !ok {
+				return nil, fmt.Errorf("значение %q должно быть числом", name)
+			}
+			values[name] = f
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("ожидается объект вида {\"имя\": число}")
+	}
+}
+
+// toVector приводит массив из JSON к []float64
+func toVector(v any) ([]float64, error) {
+	switch a := v.(type) {
+	case []float64:
+		return a, nil
+	case []any:
+		vector := make([]float64, len(a))
+		for i, raw := range a {
+			f, ok := toFloat(raw)
+			if !ok {
+				return nil, fmt.Errorf("элемент %d должен быть числом", i+1)
+			}
+			vector[i] = f
+		}
+		return vector, nil
+	default:
+		return nil, fmt.Errorf("ожидается массив чисел")
+	}
+}
+
+// toMatrix приводит массив массивов из JSON к [][]float64
+func toMatrix(v any) ([][]float64, error) {
+	switch a := v.(type) {
+	case [][]float64:
+		return a, nil
+	case []any:
+		matrix := make([][]float64, len(a))
+		for i, raw := range a {
+			row, err := toVector(raw)
+			if err != nil {
+				return nil, fmt.Errorf("строка %d: %w", i+1, err)
+			}
+			matrix[i] = row
+		}
+		return matrix, nil
+	default:
+		return nil, fmt.Errorf("ожидается массив строк матрицы")
+	}
+}
+
+// toStrings приводит массив из JSON к []string
+func toStrings(v any) ([]string, error) {
+	switch a := v.(type) {
+	case []string:
+		return a, nil
+	case []any:
+		strs := make([]string, len(a))
+		for i, raw := range a {
+			s, ok := raw.(string)
+			if !ok {
+				return nil, fmt.Errorf("элемент %d должен быть строкой", i+1)
+			}
+			strs[i] = s
+		}
+		return strs, nil
+	default:
+		return nil, fmt.Errorf("ожидается массив строк")
+	}
+}
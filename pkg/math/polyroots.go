@@ -0,0 +1,230 @@
+package math
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/mathutils"
+	"gonum.org/v1/gonum/mat"
+)
+
+// PolyRoots - все корни многочлена, найденные методом Аберта - Эрлиха
+type PolyRoots struct {
+	Coeffs     []float64    // Коэффициенты по возрастанию степеней: a0 + a1*x + ... + an*x^n
+	Roots      []complex128 // Корни, отсортированные по вещественной, затем по мнимой части
+	Eigen      []complex128 // Собственные значения матрицы Фробениуса в том же порядке, что и Roots
+	Deviation  float64      // Наибольшее расстояние между корнем и соответствующим собственным значением
+	Iterations int
+	Trace      [][]complex128 // Приближения ко всем корням после каждой итерации
+}
+
+// polySpecs - параметры поиска корней многочлена
+var polySpecs = []ParamSpec{formulaParam, paramsParam, epsilonParam}
+
+// PolynomialRoots проверяет, что формула - многочлен от x, и находит все его
+// корни, включая комплексные.
+//
+// Корни ищутся одновременно методом Аберта - Эрлиха: каждое приближение z_k
+// сдвигается на поправку Ньютона, скорректированную с учетом остальных корней,
+//
+//	w_k = (p/p') / (1 - (p/p') * Σ_{j≠k} 1/(z_k - z_j)),
+//
+// что не дает двум приближениям сойтись к одному корню. Сходимость кубическая
+// для простых корней. Для контроля те же корни вычисляются как собственные
+// значения сопровождающей матрицы (матрицы Фробениуса) многочлена.
+//
+// Если итерации не сошлись, возвращаются последние приближения и траектория
+// вместе с ошибкой. Прерывается так же, как Solver.
+func PolynomialRoots(ctx context.Context, p Params) (PolyRoots, error) {
+	var res PolyRoots
+
+	checked, err := p.validate(polySpecs)
+	if err != nil {
+		return res, err
+	}
+	formula, params := checked.String("formula"), checked.Values("params")
+	eps := checked.Float("epsilon")
+
+	tree, err := mathutils.ParseTree(formula)
+	if err != nil {
+		return res, err
+	}
+	// Компиляция проверяет имена переменных и параметров и дает понятные ошибки с позицией
+	if _, err := mathutils.Compile(tree, formula, params, "x"); err != nil {
+		return res, err
+	}
+	coeffs, err := mathutils.Polynomial(tree, "x", params)
+	if err != nil {
+		return res, err
+	}
+	for _, c := range coeffs {
+		if isBad(c) {
+			return res, fmt.Errorf("коэффициенты многочлена должны быть конечными числами")
+		}
+	}
+	res.Coeffs = coeffs
+
+	n := len(coeffs) - 1
+	if n < 1 {
+		return res, fmt.Errorf("многочлен нулевой степени не имеет корней")
+	}
+
+	// Несошедшиеся приближения тоже сверяются с собственными значениями:
+	// расхождение показывает, насколько они далеки от корней
+	aberthErr := res.aberth(newMeter(ctx), eps)
+	var ie *InterruptedError
+	if res.Roots == nil || errors.As(aberthErr, &ie) {
+		return res, aberthErr
+	}
+	sortComplex(res.Roots)
+
+	eigen, err := companionEigenvalues(coeffs)
+	if err != nil {
+		return res, err
+	}
+	res.Eigen, res.Deviation = matchRoots(res.Roots, eigen)
+
+	return res, aberthErr
+}
+
+// aberth выполняет итерации Аберта - Эрлиха до тех пор, пока все поправки не
+// станут меньше eps. Каждое вычисление многочлена с производной по схеме
+// Горнера учитывается как одно вычисление функции. Roots остается пустым,
+// только если поправку не удалось вычислить.
+func (res *PolyRoots) aberth(mt *meter, eps float64) error {
+	coeffs := res.Coeffs
+	n := len(coeffs) - 1
+
+	// Начальные приближения - точки на окружности радиуса, оценивающего
+	// модули корней сверху (граница Фудзивары). Сдвиг угла нарушает
+	// симметрию относительно вещественной оси, иначе сопряженные корни не разделятся.
+	lead := coeffs[n]
+	radius := 0.0
+	for k := 1; k <= n; k++ {
+		radius = math.Max(radius, math.Pow(math.Abs(coeffs[n-k]/lead), 1/float64(k)))
+	}
+	radius = math.Max(2*radius, machineEpsilon)
+
+	z := make([]complex128, n)
+	for k := range z {
+		z[k] = cmplx.Rect(radius, 2*math.Pi*float64(k)/float64(n)+aberthAngleShift)
+	}
+	res.Trace = append(res.Trace, append([]complex128(nil), z...))
+
+	for i := 1; i <= maxPolyIter; i++ {
+		if err := mt.check(); err != nil {
+			res.Roots = z
+			return err
+		}
+		res.Iterations = i
+
+		converged := true
+		for k := range z {
+			mt.tick()
+			v, dv := horner(coeffs, z[k])
+			if v == 0 {
+				continue
+			}
+			ratio := v / dv
+
+			var sum complex128
+			for j := range z {
+				if j != k {
+					sum += 1 / (z[k] - z[j])
+				}
+			}
+
+			w := ratio / (1 - ratio*sum)
+			if cmplx.IsNaN(w) || cmplx.IsInf(w) {
+				return fmt.Errorf("ошибка вычисления поправки для корня %d", k+1)
+			}
+			z[k] -= w
+
+			if cmplx.Abs(w) > eps*math.Max(1, cmplx.Abs(z[k])) {
+				converged = false
+			}
+		}
+		res.Trace = append(res.Trace, append([]complex128(nil), z...))
+
+		if converged {
+			// Вещественный многочлен: мнимая часть вещественных корней - шум округления
+			for k := range z {
+				if math.Abs(imag(z[k])) <= eps*math.Max(1, cmplx.Abs(z[k])) {
+					z[k] = complex(real(z[k]), 0)
+				}
+			}
+			res.Roots = z
+			return nil
+		}
+	}
+
+	res.Roots = z
+	return fmt.Errorf("превышено максимальное количество итераций")
+}
+
+// horner вычисляет значение многочлена и его производной в точке z по схеме Горнера
+func horner(coeffs []float64, z complex128) (p, dp complex128) {
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		dp = dp*z + p
+		p = p*z + complex(coeffs[i], 0)
+	}
+	return p, dp
+}
+
+// companionEigenvalues вычисляет корни многочлена как собственные значения
+// сопровождающей матрицы: единицы под главной диагональю, в последнем
+// столбце - коэффициенты приведенного многочлена с обратным знаком
+func companionEigenvalues(coeffs []float64) ([]complex128, error) {
+	n := len(coeffs) - 1
+	lead := coeffs[n]
+
+	companion := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			companion.Set(i, i-1, 1)
+		}
+		companion.Set(i, n-1, -coeffs[i]/lead)
+	}
+
+	var eig mat.Eigen
+	if ok := eig.Factorize(companion, mat.EigenNone); !ok {
+		return nil, fmt.Errorf("не удалось вычислить собственные значения сопровождающей матрицы")
+	}
+	return eig.Values(nil), nil
+}
+
+// matchRoots сопоставляет каждому корню ближайшее еще не занятое собственное
+// значение и возвращает их в порядке корней вместе с наибольшим расхождением
+func matchRoots(roots, eigen []complex128) ([]complex128, float64) {
+	matched := make([]complex128, len(roots))
+	used := make([]bool, len(eigen))
+	deviation := 0.0
+
+	for i, r := range roots {
+		best := -1
+		for j, e := range eigen {
+			if !used[j] && (best < 0 || cmplx.Abs(r-e) < cmplx.Abs(r-eigen[best])) {
+				best = j
+			}
+		}
+		used[best] = true
+		matched[i] = eigen[best]
+		deviation = math.Max(deviation, cmplx.Abs(r-eigen[best]))
+	}
+
+	return matched, deviation
+}
+
+// sortComplex упорядочивает числа по вещественной, затем по мнимой части
+func sortComplex(z []complex128) {
+	sort.Slice(z, func(i, j int) bool {
+		if real(z[i]) != real(z[j]) {
+			return real(z[i]) < real(z[j])
+		}
+		return imag(z[i]) < imag(z[j])
+	})
+}
@@ -0,0 +1,160 @@
+package math
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// ErrUnknownMethod возвращается, если метод с указанным именем не зарегистрирован
+var ErrUnknownMethod = errors.New("неизвестный метод")
+
+// ParamType - тип входного параметра метода
+type ParamType string
+
+const (
+	ParamFormula ParamType = "formula" // Строка с формулой
+	ParamNumber  ParamType = "number"  // Вещественное число
+	ParamString  ParamType = "string"  // Произвольная строка (например, режим работы)
+	ParamValues  ParamType = "values"  // Объект "имя -> число" (например, параметры формулы)
+	ParamVector  ParamType = "vector"  // Массив чисел (например, начальное приближение системы)
+	ParamNames   ParamType = "names"   // Массив имен (например, неизвестные системы)
+	ParamSystem  ParamType = "system"  // Массив формул (уравнения системы)
+	ParamMatrix  ParamType = "matrix"  // Массив строк матрицы (массивов чисел)
+)
+
+// ParamSpec описывает один входной параметр метода
+type ParamSpec struct {
+	Name        string
+	Type        ParamType
+	Required    bool
+	Default     any
+	Description string
+}
+
+// Method - описание зарегистрированного метода: имя, схема параметров и фабрика
+type Method struct {
+	Name   string
+	Title  string
+	Params []ParamSpec
+
+	// FixedPoint - формула задает не f(x), а φ(x) для уравнения x = φ(x)
+	FixedPoint bool
+
+	// New создает экземпляр метода по уже проверенным параметрам
+	New func(p Params) (Solver, error)
+}
+
+// registry - потокобезопасный реестр методов одного семейства (корни,
+// системы, СЛАУ, собственные значения, ОДУ). У каждого семейства свой реестр:
+// имена методов в разных семействах могут совпадать.
+type registry[M any] struct {
+	kind    string // Название семейства для сообщения о повторной регистрации
+	mu      sync.RWMutex
+	methods map[string]M
+}
+
+func newRegistry[M any](kind string) *registry[M] {
+	return &registry[M]{kind: kind, methods: make(map[string]M)}
+}
+
+// add добавляет метод. Повторная регистрация одного имени считается ошибкой программиста.
+func (r *registry[M]) add(name string, m M) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, dup := r.methods[name]; dup {
+		panic("math: " + r.kind + " " + name + " зарегистрирован дважды")
+	}
+	r.methods[name] = m
+}
+
+// lookup возвращает метод по имени
+func (r *registry[M]) lookup(name string) (M, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	m, ok := r.methods[name]
+	return m, ok
+}
+
+// all возвращает все методы, отсортированные по имени
+func (r *registry[M]) all() []M {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := slices.Sorted(maps.Keys(r.methods))
+	methods := make([]M, len(names))
+	for i, name := range names {
+		methods[i] = r.methods[name]
+	}
+	return methods
+}
+
+var rootMethods = newRegistry[Method]("метод")
+
+// Register добавляет метод в реестр. Вызывается из init() файла с методом.
+// Повторная регистрация одного имени считается ошибкой программиста.
+func Register(m Method) {
+	if m.Name == "" || m.New == nil {
+		panic("math: Register вызван с пустым именем или фабрикой")
+	}
+	// Критерии остановки общие для всех методов
+	m.Params = append(slices.Clip(m.Params), stopParams...)
+	rootMethods.add(m.Name, m)
+}
+
+// Lookup возвращает метод по имени
+func Lookup(name string) (Method, bool) {
+	return rootMethods.lookup(name)
+}
+
+// Methods возвращает все зарегистрированные методы, отсортированные по имени
+func Methods() []Method {
+	return rootMethods.all()
+}
+
+// NewSolver находит метод по имени, проверяет параметры по его схеме и создает решатель
+func NewSolver(name string, p Params) (Solver, error) {
+	m, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMethod, name)
+	}
+
+	checked, err := p.validate(m.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	stop, err := stopCriteria(checked)
+	if err != nil {
+		return nil, err
+	}
+
+	solver, err := m.New(checked)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := solver.(stoppable); ok {
+		s.setStop(stop)
+	}
+
+	// Формула уже проверена фабрикой метода, повторная компиляция нужна для диагностики
+	f, err := compile(checked.String("formula"), checked.Values("params"))
+	if err != nil {
+		return nil, err
+	}
+	return &diagnosedSolver{Solver: solver, method: m, f: f}, nil
+}
+
+// Общие параметры, которые принимают все методы поиска корней
+var (
+	formulaParam = ParamSpec{Name: "formula", Type: ParamFormula, Required: true, Description: "Функция f(x) или уравнение"}
+	paramsParam  = ParamSpec{Name: "params", Type: ParamValues, Description: "Значения параметров формулы, например {\"a\": 2}"}
+	epsilonParam = ParamSpec{Name: "epsilon", Type: ParamNumber, Required: true, Description: "Требуемая точность"}
+	x0Param      = ParamSpec{Name: "x0", Type: ParamNumber, Required: true, Description: "Начальное приближение"}
+	aParam       = ParamSpec{Name: "a", Type: ParamNumber, Required: true, Description: "Левая граница отрезка"}
+	bParam       = ParamSpec{Name: "b", Type: ParamNumber, Required: true, Description: "Правая граница отрезка"}
+)
@@ -0,0 +1,137 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+func init() {
+	Register(Method{
+		Name:   "ridders",
+		Title:  "Метод Риддерса",
+		Params: []ParamSpec{formulaParam, paramsParam, epsilonParam, aParam, bParam},
+		New: func(p Params) (Solver, error) {
+			return NewRiddersMethodCalculator(p.String("formula"), p.Values("params"), p.Float("a"), p.Float("b"), p.Float("epsilon"))
+		},
+	})
+}
+
+// StepRidders - шаг метода Риддерса (середина отрезка с экспоненциальной поправкой)
+const StepRidders = "ridders"
+
+// RiddersMethodCalculator реализует метод Риддерса: по значениям на концах
+// и в середине отрезка функция "выпрямляется" множителем exp(Qx), после чего
+// новая точка находится линейной интерполяцией. Новая точка всегда лежит
+// внутри отрезка, а сходимость квадратичная.
+type RiddersMethodCalculator struct {
+	stopper
+
+	Func    func(float64) float64
+	A       float64
+	B       float64
+	Epsilon float64
+}
+
+func NewRiddersMethodCalculator(funcStr string, params map[string]float64, a, b, epsilon float64) (*RiddersMethodCalculator, error) {
+	fn, err := compile(funcStr, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RiddersMethodCalculator{
+		Func:    fn,
+		A:       a,
+		B:       b,
+		Epsilon: epsilon,
+	}, nil
+}
+
+func (c *RiddersMethodCalculator) Calculate(ctx context.Context) (Result, error) {
+	mt := newMeter(ctx)
+	f := mt.wrap(c.Func)
+
+	var res Result
+
+	xl, xh := c.A, c.B
+	fl, fh := f(xl), f(xh)
+
+	if isBad(fl) {
+		return res, fmt.Errorf("ошибка вычисления функции в точке a=%v", xl)
+	}
+	if isBad(fh) {
+		return res, fmt.Errorf("ошибка вычисления функции в точке b=%v", xh)
+	}
+	if fl*fh > 0 {
+		return res, fmt.Errorf("функция имеет одинаковые знаки на концах отрезка")
+	}
+	if fl == 0 {
+		res.Root, res.StopReason = xl, StopExact
+		return res, nil
+	}
+	if fh == 0 {
+		res.Root, res.StopReason = xh, StopExact
+		return res, nil
+	}
+
+	x := xl
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		xm := 0.5 * (xl + xh)
+		fm := f(xm)
+		if isBad(fm) {
+			return res, fmt.Errorf("ошибка вычисления функции в точке x=%v", xm)
+		}
+
+		s := math.Sqrt(fm*fm - fl*fh)
+		if s == 0 {
+			res.Root, res.StopReason = xm, StopExact
+			return res, nil
+		}
+
+		xNew := xm + (xm-xl)*math.Copysign(1, fl-fh)*fm/s
+		fNew := f(xNew)
+		if isBad(fNew) {
+			return res, fmt.Errorf("ошибка вычисления функции в точке x=%v", xNew)
+		}
+
+		res.Steps = record(mt, res.Steps, Step{XPrev: x, XNew: xNew, Fx: fNew, A: xl, B: xh, Segment: true, Kind: StepRidders})
+
+		step := math.Abs(xNew - x)
+		reason := c.Stop.done(step < c.Epsilon, StopStep, step, xNew, f)
+		if fNew == 0 {
+			reason = StopExact
+		}
+		if reason != "" {
+			res.Root, res.StopReason = xNew, reason
+			return res, nil
+		}
+		x = xNew
+
+		// Выбираем новый отрезок среди [xl, xm, xNew, xh], на концах которого f меняет знак
+		switch {
+		case math.Signbit(fm) != math.Signbit(fNew):
+			xl, fl = xm, fm
+			xh, fh = xNew, fNew
+			if xl > xh {
+				xl, xh, fl, fh = xh, xl, fh, fl
+			}
+		case math.Signbit(fl) != math.Signbit(fNew):
+			xh, fh = xNew, fNew
+		default:
+			xl, fl = xNew, fNew
+		}
+
+		if reason := c.Stop.own(math.Abs(xh-xl) < c.Epsilon, StopBracket); reason != "" {
+			res.Root, res.StopReason = xNew, reason
+			return res, nil
+		}
+	}
+
+	res.StopReason = StopMaxIter
+	return res, fmt.Errorf("превышено максимальное количество итераций")
+}
@@ -0,0 +1,99 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+func init() {
+	Register(Method{
+		Name:  "secant",
+		Title: "Метод секущих",
+		Params: []ParamSpec{
+			formulaParam,
+			paramsParam,
+			epsilonParam,
+			x0Param,
+			{Name: "x1", Type: ParamNumber, Required: true, Description: "Второе начальное приближение"},
+		},
+		New: func(p Params) (Solver, error) {
+			return NewSecantMethodCalculator(p.String("formula"), p.Values("params"), p.Float("x0"), p.Float("x1"), p.Float("epsilon"))
+		},
+	})
+}
+
+// SecantMethodCalculator реализует метод секущих: производная в методе Ньютона
+// заменяется наклоном хорды, проведенной через два последних приближения.
+type SecantMethodCalculator struct {
+	stopper
+
+	Func    func(float64) float64
+	X0      float64
+	X1      float64
+	Epsilon float64
+}
+
+func NewSecantMethodCalculator(funcStr string, params map[string]float64, x0, x1, epsilon float64) (*SecantMethodCalculator, error) {
+	fn, err := compile(funcStr, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if x0 == x1 {
+		return nil, fmt.Errorf("начальные приближения x0 и x1 должны различаться")
+	}
+
+	return &SecantMethodCalculator{
+		Func:    fn,
+		X0:      x0,
+		X1:      x1,
+		Epsilon: epsilon,
+	}, nil
+}
+
+func (c *SecantMethodCalculator) Calculate(ctx context.Context) (Result, error) {
+	mt := newMeter(ctx)
+	f := mt.wrap(c.Func)
+
+	var res Result
+
+	xPrev, x := c.X0, c.X1
+	fPrev := f(xPrev)
+	if isBad(fPrev) {
+		return res, fmt.Errorf("ошибка вычисления функции в точке x=%v", xPrev)
+	}
+
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		fx := f(x)
+		if isBad(fx) {
+			return res, fmt.Errorf("ошибка вычисления функции в точке x=%v", x)
+		}
+
+		// Хорда параллельна оси абсцисс - пересечения нет
+		if math.Abs(fx-fPrev) < 1e-14 {
+			return res, fmt.Errorf("хорда через точки x=%v и x=%v горизонтальна", xPrev, x)
+		}
+
+		xNew := x - fx*(x-xPrev)/(fx-fPrev)
+		res.Steps = record(mt, res.Steps, Step{XPrev: x, XNew: xNew, Fx: fx, A: xPrev, B: x, Segment: true})
+
+		step := math.Abs(xNew - x)
+		if reason := c.Stop.done(step < c.Epsilon, StopStep, step, xNew, f); reason != "" {
+			res.Root, res.StopReason = xNew, reason
+			return res, nil
+		}
+
+		xPrev, fPrev = x, fx
+		x = xNew
+	}
+
+	res.Root = x
+	res.StopReason = StopMaxIter
+	return res, fmt.Errorf("превышено максимальное количество итераций")
+}
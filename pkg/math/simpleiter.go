@@ -1,27 +1,106 @@
 package math
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
 
 	"github.com/GeorgeTyupin/numerical_methods/pkg/math/mathutils"
-	"github.com/Knetic/govaluate"
 )
 
-type SimpleIterStep struct {
-	XPrev float64
-	XNew  float64
-	Fx    float64
+func init() {
+	Register(Method{
+		Name:       "simple_iter",
+		Title:      "Метод простой итерации",
+		FixedPoint: true,
+		Params: []ParamSpec{
+			{Name: "formula", Type: ParamFormula, Required: true, Description: "Функция phi(x) для итерации x = phi(x) или f(x) в режиме mode = \"f\""},
+			paramsParam,
+			epsilonParam,
+			x0Param,
+			{Name: "a", Type: ParamNumber, Description: "Левая граница отрезка для проверки сходимости (по умолчанию - окрестность x0)"},
+			{Name: "b", Type: ParamNumber, Description: "Правая граница отрезка для проверки сходимости"},
+			{Name: "mode", Type: ParamString, Default: IterModePhi, Description: "phi - формула задает phi(x); f - формула задает f(x), и phi(x) = x - λf(x) строится автоматически"},
+			{Name: "acceleration", Type: ParamString, Default: AccelNone, Description: "Ускорение сходимости: none, aitken (экстраполяция Эйткена) или steffensen (метод Стеффенсена)"},
+		},
+		New: func(p Params) (Solver, error) {
+			x0 := p.Float("x0")
+			a, b := x0, x0
+			if p.Has("a") || p.Has("b") {
+				if !p.Has("a") || !p.Has("b") || !(p.Float("a") < p.Float("b")) {
+					return nil, fmt.Errorf("для проверки сходимости нужны обе границы отрезка, a < b")
+				}
+				a, b = p.Float("a"), p.Float("b")
+			}
+
+			accel := p.String("acceleration")
+			switch accel {
+			case AccelNone, AccelAitken, AccelSteffensen:
+			default:
+				return nil, fmt.Errorf("неизвестный способ ускорения %q, ожидается %q, %q или %q", accel, AccelNone, AccelAitken, AccelSteffensen)
+			}
+
+			var c *SimpleIterationMethodCalculator
+			var err error
+			switch p.String("mode") {
+			case IterModePhi:
+				c, err = NewSimpleIterationMethodCalculator(p.String("formula"), p.Values("params"), x0, p.Float("epsilon"))
+				if err == nil {
+					c.A, c.B = a, b
+				}
+			case IterModeEquation:
+				c, err = NewSimpleIterationFromEquation(p.String("formula"), p.Values("params"), x0, p.Float("epsilon"), a, b)
+			default:
+				return nil, fmt.Errorf("неизвестный режим %q, ожидается %q или %q", p.String("mode"), IterModePhi, IterModeEquation)
+			}
+			if err != nil {
+				return nil, err
+			}
+			c.Acceleration = accel
+			return c, nil
+		},
+	})
 }
 
+// Способы ускорения сходимости простой итерации
+const (
+	AccelNone       = "none"
+	AccelAitken     = "aitken"     // Δ²-процесс Эйткена над последовательностью x_n+1 = φ(x_n)
+	AccelSteffensen = "steffensen" // Метод Стеффенсена: Δ²-поправка после каждых двух вычислений φ
+)
+
+// Режимы метода простой итерации
+const (
+	IterModePhi      = "phi" // Формула задает φ(x)
+	IterModeEquation = "f"   // Формула задает f(x), φ(x) = x - λf(x)
+)
+
 type SimpleIterationMethodCalculator struct {
-	Func    *govaluate.EvaluableExpression
+	stopper
+
+	Func    func(float64) float64
 	X0      float64
 	Epsilon float64
+
+	// Отрезок для предварительной проверки сходимости. Если не задан (A == B),
+	// проверяется окрестность x0.
+	A float64
+	B float64
+
+	// Способ ускорения сходимости: AccelNone, AccelAitken или AccelSteffensen
+	Acceleration string
+
+	// Заполняются, если φ построена по уравнению f(x) = 0
+	Lambda     float64
+	PhiFormula string
+	equation   func(float64) float64
+
+	deriv derivatives
 }
 
-func NewSimpleIterationMethodCalculator(funcStr string, x0, epsilon float64) (*SimpleIterationMethodCalculator, error) {
-	fn, err := mathutils.ParseFormula(funcStr)
+func NewSimpleIterationMethodCalculator(funcStr string, params map[string]float64, x0, epsilon float64) (*SimpleIterationMethodCalculator, error) {
+	fn, err := compile(funcStr, params)
 	if err != nil {
 		return nil, err
 	}
@@ -30,44 +109,237 @@ func NewSimpleIterationMethodCalculator(funcStr string, x0, epsilon float64) (*S
 		Func:    fn,
 		X0:      x0,
 		Epsilon: epsilon,
+		deriv:   newDerivatives(funcStr, params, fn),
 	}, nil
 }
 
-func (c *SimpleIterationMethodCalculator) eval(x float64) float64 {
-	res, _ := c.Func.Evaluate(map[string]interface{}{"x": x, "pi": math.Pi, "e": math.E})
-	val, ok := res.(float64)
-	if !ok {
-		return math.NaN()
+// NewSimpleIterationFromEquation строит метод простой итерации для уравнения
+// f(x) = 0, приводя его к виду x = φ(x), φ(x) = x - λf(x).
+//
+// Если на отрезке [a, b] производная f' не меняет знак и 0 < m <= |f'(x)| <= M,
+// то при λ = sign(f')·2/(m + M) получаем φ'(x) = 1 - λf'(x) и
+//
+//	q = max|φ'(x)| = (M - m)/(M + m) < 1,
+//
+// то есть наименьшее возможное q для такого вида φ. Если отрезок не задан
+// (a == b), используется окрестность x0.
+func NewSimpleIterationFromEquation(funcStr string, params map[string]float64, x0, epsilon, a, b float64) (*SimpleIterationMethodCalculator, error) {
+	f, err := compile(funcStr, params)
+	if err != nil {
+		return nil, err
+	}
+	if a == b {
+		a, b = contractionInterval(x0)
+	}
+
+	// Границы |f'| на отрезке
+	df := newDerivatives(funcStr, params, f).first
+	m, M := math.Inf(1), 0.0
+	sign := 0.0
+	for i := 0; i <= contractionSamples; i++ {
+		x := a + (b-a)*float64(i)/contractionSamples
+		d := df(x)
+		if isBad(d) {
+			return nil, fmt.Errorf("f'(x) не определена в точке x=%.6g отрезка [%.6g, %.6g]", x, a, b)
+		}
+		if d == 0 || (sign != 0 && math.Signbit(d) != math.Signbit(sign)) {
+			return nil, fmt.Errorf("f'(x) обращается в ноль или меняет знак на отрезке [%.6g, %.6g], подобрать λ нельзя", a, b)
+		}
+		sign = d
+		m, M = math.Min(m, math.Abs(d)), math.Max(M, math.Abs(d))
+	}
+	lambda := math.Copysign(2/(m+M), sign)
+
+	tree, err := mathutils.ParseTree(funcStr)
+	if err != nil {
+		return nil, err
+	}
+	// x - λ*f записываем с положительным коэффициентом, чтобы формула читалась естественно
+	op := '-'
+	if lambda < 0 {
+		op = '+'
+	}
+	phiTree := &mathutils.Binary{
+		Op: op,
+		L:  &mathutils.Var{Name: "x"},
+		R:  &mathutils.Binary{Op: '*', L: &mathutils.Num{Value: math.Abs(lambda)}, R: tree},
+	}
+	phiFormula := phiTree.String()
+
+	c, err := NewSimpleIterationMethodCalculator(phiFormula, params, x0, epsilon)
+	if err != nil {
+		return nil, err
+	}
+	c.A, c.B = a, b
+	c.Lambda, c.PhiFormula, c.equation = lambda, phiFormula, f
+	return c, nil
+}
+
+// contractionInterval возвращает окрестность x0, в которой проверяется сходимость
+func contractionInterval(x0 float64) (float64, float64) {
+	r := contractionRadius * math.Max(1, math.Abs(x0))
+	return x0 - r, x0 + r
+}
+
+// diagnosticFunc - для диагностики нужна φ, а не формула запроса (она может задавать f)
+func (c *SimpleIterationMethodCalculator) diagnosticFunc() func(float64) float64 {
+	return c.Func
+}
+
+func (c *SimpleIterationMethodCalculator) Calculate(ctx context.Context) (Result, error) {
+	var res Result
+	mt := newMeter(ctx)
+
+	// Перед итерациями проверяем достаточные условия сходимости
+	a, b := c.A, c.B
+	if a == b {
+		a, b = contractionInterval(c.X0)
+	}
+	check := CheckContraction(mt.wrap(c.Func), c.deriv.metered(mt).first, c.X0, a, b, c.Epsilon)
+	res.Info = check.info()
+	if c.PhiFormula != "" {
+		res.Info["lambda"] = c.Lambda
+		res.Info["phi"] = c.PhiFormula
+	}
+
+	var err error
+	switch c.Acceleration {
+	case AccelAitken, AccelSteffensen:
+		res, err = c.accelerate(mt, res)
+	default:
+		res, err = c.iterate(mt, res)
 	}
-	return val
+	if err != nil && check.Verdict != VerdictConverges {
+		err = fmt.Errorf("%w (предварительная проверка: %s)", err, check.Reason)
+	}
+	return res, err
 }
 
-func (c *SimpleIterationMethodCalculator) Calculate() ([]SimpleIterStep, float64, int, error) {
-	var steps []SimpleIterStep
+// iterate выполняет итерации x_n+1 = φ(x_n)
+func (c *SimpleIterationMethodCalculator) iterate(mt *meter, res Result) (Result, error) {
+	phi := mt.wrap(c.Func)
+	residual := c.residual(mt)
 
 	// Начальное приближение
 	xPrev := c.X0
 
-	for i := 1; i <= maxIter; i++ {
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
 		// Вычисляем новое приближение
-		xNew := c.eval(xPrev)
+		xNew := phi(xPrev)
 
-		if math.IsNaN(xNew) || math.IsInf(xNew, 0) {
-			return steps, 0, i, fmt.Errorf("ошибка: значение ушло в бесконечность (расходится) на x=%v", xPrev)
+		if isBad(xNew) {
+			return res, fmt.Errorf("ошибка: значение ушло в бесконечность (расходится) на x=%v", xPrev)
 		}
 
-		// Разница для проверки условия сходимости
-		diff := math.Abs(xNew - xPrev)
-
-		steps = append(steps, SimpleIterStep{XPrev: xPrev, XNew: xNew, Fx: diff})
+		res.Steps = record(mt, res.Steps, Step{XPrev: xPrev, XNew: xNew, Fx: math.Abs(xNew - xPrev)})
 
 		// Условие остановки: расстояние между точками меньше либо равно эпсилон
-		if diff <= c.Epsilon {
-			return steps, xNew, i, nil
+		step := math.Abs(xNew - xPrev)
+		if reason := c.Stop.done(step <= c.Epsilon, StopStep, step, xNew, residual); reason != "" {
+			res.Root, res.StopReason = xNew, reason
+			return res, nil
+		}
+
+		xPrev = xNew
+	}
+
+	res.StopReason = StopMaxIter
+	return res, fmt.Errorf("превышено максимальное количество итераций")
+}
+
+// residual возвращает невязку приближения x: f(x), если φ построена по f,
+// иначе φ(x) - x. Вычисления учитываются в mt.
+func (c *SimpleIterationMethodCalculator) residual(mt *meter) func(float64) float64 {
+	if c.equation != nil {
+		return mt.wrap(c.equation)
+	}
+	phi := mt.wrap(c.Func)
+	return func(x float64) float64 { return phi(x) - x }
+}
+
+// accelerate строит ускоренную последовательность и для сравнения сохраняет
+// в res.Raw обычную простую итерацию из той же начальной точки.
+//
+// Обе поправки используют Δ²-процесс Эйткена: по трем членам x, y = φ(x), z = φ(y)
+//
+//	x̂ = x - (y - x)² / (z - 2y + x).
+//
+// Эйткен применяет его к каждой тройке подряд идущих членов исходной
+// последовательности, а Стеффенсен продолжает итерации из x̂, что дает
+// квадратичную сходимость без вычисления производной.
+func (c *SimpleIterationMethodCalculator) accelerate(mt *meter, res Result) (Result, error) {
+	// Обычная последовательность нужна только для сравнения, ее шаги обработчику не передаются
+	onStep := mt.onStep
+	mt.onStep = nil
+	raw, rawErr := c.iterate(mt, Result{})
+	mt.onStep = onStep
+	res.Raw = raw.Steps
+	res.Info["acceleration"] = c.Acceleration
+	res.Info["raw_iterations"] = raw.Iterations
+	res.Info["raw_converged"] = rawErr == nil
+	var ie *InterruptedError
+	if errors.As(rawErr, &ie) {
+		return res, rawErr
+	}
+
+	start := mt.evals
+	phi := mt.wrap(c.Func)
+	residual := c.residual(mt)
+	defer func() { res.Info["phi_evaluations"] = mt.evals - start }()
+
+	x := c.X0
+	y := phi(x)
+	xPrev := c.X0
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		if isBad(y) {
+			return res, fmt.Errorf("ошибка: значение ушло в бесконечность (расходится) на x=%v", x)
+		}
+		z := phi(y)
+		if isBad(z) {
+			return res, fmt.Errorf("ошибка: значение ушло в бесконечность (расходится) на x=%v", y)
 		}
 
+		// Нулевая вторая разность: последовательность уже стоит на месте или
+		// меняется линейно, и поправка не определена - берем последний член
+		xNew := z
+		if d := z - 2*y + x; d != 0 {
+			xNew = x - (y-x)*(y-x)/d
+		}
+		if isBad(xNew) {
+			return res, fmt.Errorf("ошибка вычисления Δ²-поправки на x=%v", x)
+		}
+
+		// У Эйткена шаг ведет от предыдущего ускоренного члена, у Стеффенсена - из x
+		from := x
+		if c.Acceleration == AccelAitken {
+			from = xPrev
+		}
+		res.Steps = record(mt, res.Steps, Step{XPrev: from, XNew: xNew, Fx: math.Abs(xNew - from)})
+
+		step := math.Abs(xNew - from)
+		if reason := c.Stop.done(step <= c.Epsilon, StopStep, step, xNew, residual); reason != "" {
+			res.Root, res.StopReason = xNew, reason
+			return res, nil
+		}
+
+		if c.Acceleration == AccelAitken {
+			x, y = y, z
+		} else {
+			x, y = xNew, phi(xNew)
+		}
 		xPrev = xNew
 	}
 
-	return steps, 0, maxIter, fmt.Errorf("превышено максимальное количество итераций")
+	res.StopReason = StopMaxIter
+	return res, fmt.Errorf("превышено максимальное количество итераций")
 }
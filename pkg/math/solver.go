@@ -0,0 +1,68 @@
+package math
+
+import (
+	"context"
+	"math"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/mathutils"
+)
+
+// Step - унифицированная запись одного шага численного метода.
+// Точечные методы (Ньютон, простая итерация) заполняют XPrev, XNew и Fx,
+// интервальные методы и методы хорд дополнительно сохраняют отрезок [A, B]:
+// текущий интервал с корнем или концы проведенной хорды.
+type Step struct {
+	XPrev float64 // Приближение x_n до шага
+	XNew  float64 // Вычисленное приближение x_n+1
+	Fx    float64 // Значение функции, соответствующее шагу
+
+	// Отрезок, на котором работает интервальный метод, или концы хорды
+	A       float64
+	B       float64
+	Segment bool
+
+	// Тип подшага для комбинированных методов (например, "bisection", "secant", "iqi")
+	Kind string
+}
+
+// Result - итог работы метода
+type Result struct {
+	Steps      []Step
+	Root       float64
+	Iterations int
+	StopReason string // Критерий, по которому остановились итерации (Stop*)
+
+	// Исходная последовательность без ускорения - заполняется, если метод
+	// ускоряет сходимость (например, простая итерация с экстраполяцией Эйткена)
+	Raw []Step
+
+	// Дополнительные сведения, специфичные для конкретного метода
+	Info map[string]any
+
+	// Эмпирический порядок сходимости, невязки и оценки погрешности по шагам
+	Diagnostics Diagnostics
+}
+
+// Solver - общий интерфейс всех методов поиска корней. Отмена ctx или
+// исчерпание бюджета (WithEvalBudget) прерывают расчет с *InterruptedError,
+// при этом возвращаются шаги, выполненные к этому моменту.
+type Solver interface {
+	Calculate(ctx context.Context) (Result, error)
+}
+
+// compile разбирает формулу и возвращает функцию одной переменной x.
+// params - значения именованных параметров формулы (может быть nil).
+// Если выражение не определено в точке (ln(-1), 1/0), функция возвращает NaN или Inf.
+func compile(formula string, params map[string]float64) (func(float64) float64, error) {
+	prog, err := mathutils.ParseFormula(formula, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return prog.Eval1, nil
+}
+
+// isBad проверяет, что значение не является числом или бесконечностью
+func isBad(v float64) bool {
+	return math.IsNaN(v) || math.IsInf(v, 0)
+}
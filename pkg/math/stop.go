@@ -0,0 +1,147 @@
+package math
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// DefaultMaxIter - предел итераций, если он не задан в запросе
+const DefaultMaxIter = maxIter
+
+// Причины остановки итераций (Result.StopReason). Если в режиме StopAll или
+// StopAny одновременно выполнились несколько критериев, они перечисляются через "+".
+const (
+	StopStep     = "step"     // Собственный критерий метода: шаг меньше ε
+	StopBracket  = "bracket"  // Собственный критерий интервального метода: отрезок короче ε
+	StopExact    = "exact"    // Значение функции в точке равно нулю
+	StopOffDiag  = "off_diag" // Собственный критерий методов вращений и QR: внедиагональная норма меньше ε
+	StopStepAbs  = "step_abs" // |x_n+1 - x_n| < step_tol
+	StopStepRel  = "step_rel" // |x_n+1 - x_n| < rel_tol·|x_n+1|
+	StopResidual = "residual" // |f(x_n+1)| < residual_tol (или < ε у вырожденной производной)
+	StopMaxIter  = "max_iter" // Исчерпан предел итераций
+	StopAny      = "or"       // Достаточно одного из заданных критериев
+	StopAll      = "and"      // Должны выполниться все заданные критерии
+)
+
+// Параметры критериев остановки. Register добавляет их к схеме каждого метода.
+var stopParams = []ParamSpec{
+	{Name: "max_iter", Type: ParamNumber, Default: float64(maxIter), Description: "Предел числа итераций"},
+	{Name: "step_tol", Type: ParamNumber, Description: "Остановка по абсолютной длине шага: |x_n+1 - x_n| < step_tol"},
+	{Name: "rel_tol", Type: ParamNumber, Description: "Остановка по относительной длине шага: |x_n+1 - x_n| < rel_tol·|x_n+1|"},
+	{Name: "residual_tol", Type: ParamNumber, Description: "Остановка по невязке: |f(x_n+1)| < residual_tol"},
+	{Name: "stop_mode", Type: ParamString, Default: StopAny, Description: "Как объединять заданные критерии: or - любой, and - все"},
+}
+
+// StopCriteria - критерии остановки, заданные в запросе. Если не задан ни
+// один допуск, метод останавливается по собственному критерию с точностью ε.
+// У интервальных методов длиной шага считается длина текущего отрезка или
+// расстояние между соседними приближениями, как в их собственном критерии.
+type StopCriteria struct {
+	MaxIter  int     // Предел итераций, 0 - DefaultMaxIter
+	StepAbs  float64 // Допуск на абсолютную длину шага, 0 - не проверяется
+	StepRel  float64 // Допуск на относительную длину шага, 0 - не проверяется
+	Residual float64 // Допуск на невязку, 0 - не проверяется
+	All      bool    // Требовать все заданные критерии (AND), иначе любой (OR)
+}
+
+// stopCriteria собирает критерии из проверенных параметров
+func stopCriteria(p Params) (StopCriteria, error) {
+	s := StopCriteria{
+		MaxIter:  int(p.Float("max_iter")),
+		StepAbs:  p.Float("step_tol"),
+		StepRel:  p.Float("rel_tol"),
+		Residual: p.Float("residual_tol"),
+	}
+
+	if float64(s.MaxIter) != p.Float("max_iter") || s.MaxIter < 1 {
+		return s, fmt.Errorf("max_iter должен быть целым положительным числом")
+	}
+	for _, name := range []string{"step_tol", "rel_tol", "residual_tol"} {
+		if p.Has(name) && !(p.Float(name) > 0) {
+			return s, fmt.Errorf("допуск %s должен быть положительным", name)
+		}
+	}
+
+	switch p.String("stop_mode") {
+	case StopAny:
+	case StopAll:
+		s.All = true
+	default:
+		return s, fmt.Errorf("неизвестный режим остановки %q, ожидается %q или %q", p.String("stop_mode"), StopAny, StopAll)
+	}
+
+	return s, nil
+}
+
+// custom сообщает, заданы ли в запросе собственные допуски
+func (s StopCriteria) custom() bool {
+	return s.StepAbs > 0 || s.StepRel > 0 || s.Residual > 0
+}
+
+func (s StopCriteria) maxIter() int {
+	if s.MaxIter > 0 {
+		return s.MaxIter
+	}
+	return maxIter
+}
+
+// own возвращает reason, если сработал собственный критерий метода, а
+// критерии в запросе не заданы
+func (s StopCriteria) own(cond bool, reason string) string {
+	if cond && !s.custom() {
+		return reason
+	}
+	return ""
+}
+
+// check проверяет критерии запроса для приближения x после шага длины step.
+// Невязка вычисляется, только если задан допуск на нее. Возвращает причину
+// остановки или пустую строку.
+func (s StopCriteria) check(step, x float64, residual func(float64) float64) string {
+	if !s.custom() {
+		return ""
+	}
+
+	var met []string
+	total := 0
+	test := func(tol float64, ok func() bool, reason string) {
+		if tol <= 0 {
+			return
+		}
+		total++
+		if ok() {
+			met = append(met, reason)
+		}
+	}
+	test(s.StepAbs, func() bool { return step < s.StepAbs }, StopStepAbs)
+	test(s.StepRel, func() bool { return step < s.StepRel*math.Abs(x) }, StopStepRel)
+	test(s.Residual, func() bool { return math.Abs(residual(x)) < s.Residual }, StopResidual)
+
+	if len(met) == 0 || (s.All && len(met) < total) {
+		return ""
+	}
+	return strings.Join(met, "+")
+}
+
+// done объединяет оба варианта: критерии запроса, если они заданы, иначе
+// собственный критерий метода cond с причиной reason
+func (s StopCriteria) done(cond bool, reason string, step, x float64, residual func(float64) float64) string {
+	if s.custom() {
+		return s.check(step, x, residual)
+	}
+	return s.own(cond, reason)
+}
+
+// stopper встраивается в калькуляторы: NewSolver передает через него
+// критерии остановки из запроса
+type stopper struct {
+	Stop StopCriteria
+}
+
+func (s *stopper) setStop(c StopCriteria) { s.Stop = c }
+
+// stoppable реализуют калькуляторы со встроенным stopper
+type stoppable interface {
+	setStop(StopCriteria)
+}
@@ -0,0 +1,118 @@
+package math
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// SweepRange - равномерная сетка значений параметра формулы
+type SweepRange struct {
+	Param  string  // Имя параметра, например "c"
+	From   float64 // Начальное значение
+	To     float64 // Конечное значение
+	Points int     // Количество точек сетки, включая концы
+}
+
+// SweepPoint - результат решения уравнения при одном значении параметра
+type SweepPoint struct {
+	Value      float64 // Значение параметра
+	Root       float64 // Найденный корень
+	Iterations int
+	Err        error // Ошибка метода в этой точке (остальные точки при этом считаются)
+}
+
+// Sweep решает одно и то же уравнение выбранным методом для каждого значения
+// параметра на сетке и возвращает зависимость корня от параметра.
+//
+// При continuation включено продолжение по параметру: корень, найденный в
+// предыдущей точке, становится начальным приближением x0 для следующей.
+// Так метод следует за одной ветвью корней, а не перескакивает между ними.
+func Sweep(ctx context.Context, method string, p Params, r SweepRange, continuation bool) ([]SweepPoint, error) {
+	m, ok := Lookup(method)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMethod, method)
+	}
+	if r.Points < 2 || r.Points > maxSweepPoints {
+		return nil, fmt.Errorf("количество точек должно быть от 2 до %d", maxSweepPoints)
+	}
+	if r.Param == "" {
+		return nil, fmt.Errorf("не указан параметр для прогона")
+	}
+
+	base := map[string]float64{}
+	if raw, ok := p["params"]; ok && raw != nil {
+		values, err := toValues(raw)
+		if err != nil {
+			return nil, fmt.Errorf("параметр %q: %w", "params", err)
+		}
+		base = values
+	}
+
+	// Продолжение по параметру возможно только для методов с начальным приближением
+	x0Orig, hasX0 := toFloat(p["x0"])
+	x1Orig, hasX1 := toFloat(p["x1"])
+	continuation = continuation && hasX0 && m.hasParam("x0")
+
+	points := make([]SweepPoint, r.Points)
+	step := (r.To - r.From) / float64(r.Points-1)
+	x0 := x0Orig
+
+	// Обработчику передаются точки прогона, а не шаги каждого решения
+	onStep := stepHook(ctx)
+	ctx = WithStepHook(ctx, nil)
+
+	for i := range points {
+		value := r.From + float64(i)*step
+
+		values := maps.Clone(base)
+		values[r.Param] = value
+
+		input := make(Params, len(p))
+		maps.Copy(input, p)
+		input["params"] = values
+		if continuation {
+			input["x0"] = x0
+			if hasX1 && m.hasParam("x1") {
+				input["x1"] = x0 + (x1Orig - x0Orig)
+			}
+		}
+
+		// Ошибки создания метода (неверная формула или параметры) не зависят
+		// от значения параметра, поэтому прерывают весь прогон
+		solver, err := NewSolver(method, input)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := solver.Calculate(ctx)
+		point := SweepPoint{Value: value, Root: res.Root, Iterations: res.Iterations, Err: err}
+		points[i] = point
+		if onStep != nil {
+			onStep(point)
+		}
+
+		// Прерванный расчет останавливает весь прогон: возвращаем уже посчитанные точки
+		var ie *InterruptedError
+		if errors.As(err, &ie) {
+			return points[:i+1], err
+		}
+
+		if err == nil && continuation {
+			x0 = point.Root
+		}
+	}
+
+	return points, nil
+}
+
+// hasParam проверяет, есть ли параметр с таким именем в схеме метода
+func (m Method) hasParam(name string) bool {
+	for _, spec := range m.Params {
+		if spec.Name == name {
+			return true
+		}
+	}
+	return false
+}
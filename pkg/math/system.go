@@ -0,0 +1,265 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/GeorgeTyupin/numerical_methods/pkg/math/mathutils"
+	"gonum.org/v1/gonum/diff/fd"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// SystemStep - шаг метода решения системы нелинейных уравнений
+type SystemStep struct {
+	XPrev    []float64 // Текущее приближение x_n
+	XNew     []float64 // Следующее приближение x_n+1
+	Residual float64   // Норма невязки ||F(x_n+1)||
+	StepNorm float64   // Норма шага ||x_n+1 - x_n||
+}
+
+// SystemResult - результат решения системы
+type SystemResult struct {
+	Steps      []SystemStep
+	Root       []float64
+	Iterations int
+	StopReason string
+	Info       map[string]any
+}
+
+// SystemSolver - метод решения системы нелинейных уравнений F(x) = 0.
+// Прерывается так же, как Solver.
+type SystemSolver interface {
+	Calculate(ctx context.Context) (SystemResult, error)
+}
+
+// SystemMethod - описание метода решения систем: имя, схема параметров и фабрика
+type SystemMethod struct {
+	Name   string
+	Title  string
+	Params []ParamSpec
+
+	// New создает экземпляр метода по уже проверенным параметрам
+	New func(p Params) (SystemSolver, error)
+}
+
+// Методы для систем регистрируются отдельно от скалярных: у них другие
+// параметры и результат, а имена (например, newton) могут совпадать
+var systemMethods = newRegistry[SystemMethod]("метод для систем")
+
+// RegisterSystem добавляет метод решения систем в реестр
+func RegisterSystem(m SystemMethod) {
+	if m.Name == "" || m.New == nil {
+		panic("math: RegisterSystem вызван с пустым именем или фабрикой")
+	}
+	systemMethods.add(m.Name, m)
+}
+
+// SystemMethods возвращает все методы решения систем, отсортированные по имени
+func SystemMethods() []SystemMethod {
+	return systemMethods.all()
+}
+
+// NewSystemSolver находит метод решения систем по имени, проверяет параметры и создает решатель
+func NewSystemSolver(name string, p Params) (SystemSolver, error) {
+	m, ok := systemMethods.lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMethod, name)
+	}
+
+	checked, err := p.validate(m.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	solver, err := m.New(checked)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := solver.(stoppable); ok {
+		stop, err := stopCriteria(checked)
+		if err != nil {
+			return nil, err
+		}
+		s.setStop(stop)
+	}
+	return solver, nil
+}
+
+// Общие параметры методов решения систем
+var (
+	equationsParam = ParamSpec{Name: "equations", Type: ParamSystem, Required: true, Description: "Уравнения системы, например [\"x^2 + y^2 = 4\", \"x*y = 1\"]"}
+	varsParam      = ParamSpec{Name: "vars", Type: ParamNames, Description: "Неизвестные в порядке компонент x0; по умолчанию - все переменные уравнений по алфавиту"}
+	x0VectorParam  = ParamSpec{Name: "x0", Type: ParamVector, Required: true, Description: "Начальное приближение"}
+	systemParams   = slices.Concat([]ParamSpec{equationsParam, varsParam, paramsParam, epsilonParam, x0VectorParam}, stopParams)
+)
+
+// system - скомпилированная система уравнений F(x) = 0
+type system struct {
+	vars   []string
+	trees  []mathutils.Node
+	progs  []*mathutils.Program
+	params map[string]float64
+
+	evaluations int    // Количество вычислений F
+	mt          *meter // Учет вычислений текущего расчета, nil - без учета
+}
+
+// compileSystem разбирает уравнения системы. Если неизвестные не заданы,
+// ими становятся все переменные уравнений, кроме параметров, в алфавитном порядке.
+func compileSystem(equations, vars []string, params map[string]float64, x0 []float64) (*system, error) {
+	if len(equations) == 0 || len(equations) > maxSystemSize {
+		return nil, fmt.Errorf("система должна содержать от 1 до %d уравнений", maxSystemSize)
+	}
+
+	s := &system{vars: vars, params: params}
+	for _, eq := range equations {
+		tree, err := mathutils.ParseTree(eq)
+		if err != nil {
+			return nil, err
+		}
+		s.trees = append(s.trees, tree)
+	}
+
+	if len(s.vars) == 0 {
+		seen := make(map[string]bool)
+		for _, tree := range s.trees {
+			for _, name := range mathutils.FreeVariables(tree, params) {
+				if !seen[name] {
+					seen[name] = true
+					s.vars = append(s.vars, name)
+				}
+			}
+		}
+		sort.Strings(s.vars)
+	}
+
+	if len(s.vars) != len(equations) {
+		return nil, fmt.Errorf("число уравнений (%d) должно совпадать с числом неизвестных (%d: %v)", len(equations), len(s.vars), s.vars)
+	}
+	if len(x0) != len(s.vars) {
+		return nil, fmt.Errorf("начальное приближение должно содержать %d компонент (%v)", len(s.vars), s.vars)
+	}
+
+	for i, tree := range s.trees {
+		prog, err := mathutils.Compile(tree, equations[i], params, s.vars...)
+		if err != nil {
+			return nil, fmt.Errorf("уравнение %d: %w", i+1, err)
+		}
+		s.progs = append(s.progs, prog)
+	}
+
+	return s, nil
+}
+
+// eval вычисляет F(x) в dst
+func (s *system) eval(dst, x []float64) {
+	s.evaluations++
+	if s.mt != nil {
+		s.mt.tick()
+	}
+	for i, prog := range s.progs {
+		dst[i] = prog.Eval(x)
+	}
+}
+
+// jacobian - матрица Якоби системы. Строится символьно; если хотя бы одну
+// частную производную построить не удалось, используются конечные разности.
+type jacobian struct {
+	sys      *system
+	partials [][]*mathutils.Program // partials[i][j] = dF_i/dx_j, nil для численной матрицы
+	formulas [][]string
+}
+
+func newJacobian(s *system) jacobian {
+	j := jacobian{sys: s}
+
+	n := len(s.vars)
+	partials := make([][]*mathutils.Program, n)
+	formulas := make([][]string, n)
+	for i, tree := range s.trees {
+		partials[i] = make([]*mathutils.Program, n)
+		formulas[i] = make([]string, n)
+		for k, v := range s.vars {
+			d, err := mathutils.Derivative(tree, v)
+			if err != nil {
+				return j
+			}
+			prog, err := mathutils.Compile(d, d.String(), s.params, s.vars...)
+			if err != nil {
+				return j
+			}
+			partials[i][k], formulas[i][k] = prog, d.String()
+		}
+	}
+
+	j.partials, j.formulas = partials, formulas
+	return j
+}
+
+// at вычисляет матрицу Якоби в точке x
+func (j jacobian) at(x []float64) *mat.Dense {
+	n := len(x)
+	dst := mat.NewDense(n, n, nil)
+
+	if j.partials == nil {
+		fd.Jacobian(dst, j.sys.eval, x, &fd.JacobianSettings{Formula: fd.Central})
+		return dst
+	}
+
+	// n² частных производных по стоимости - примерно n вычислений системы,
+	// столько же, сколько у численной матрицы
+	if j.sys.mt != nil {
+		for range n {
+			j.sys.mt.tick()
+		}
+	}
+	for i, row := range j.partials {
+		for k, prog := range row {
+			dst.Set(i, k, prog.Eval(x))
+		}
+	}
+	return dst
+}
+
+// info возвращает сведения о матрице Якоби для ответа
+func (j jacobian) info() map[string]any {
+	if j.partials == nil {
+		return map[string]any{"jacobian_mode": "numeric"}
+	}
+	return map[string]any{"jacobian_mode": "symbolic", "jacobian": j.formulas}
+}
+
+// solveLinear решает J*dx = rhs и сообщает о вырожденной матрице
+func solveLinear(j *mat.Dense, rhs []float64) ([]float64, error) {
+	var dx mat.VecDense
+	err := dx.SolveVec(j, mat.NewVecDense(len(rhs), rhs))
+	if cond, ok := err.(mat.Condition); err != nil && (!ok || float64(cond) > 1/machineEpsilon) {
+		return nil, fmt.Errorf("матрица Якоби вырождена")
+	}
+	return dx.RawVector().Data, nil
+}
+
+// isBadVector проверяет, что среди компонент есть NaN или бесконечность
+func isBadVector(v []float64) bool {
+	for _, x := range v {
+		if isBad(x) {
+			return true
+		}
+	}
+	return false
+}
+
+// systemStop проверяет критерии остановки для систем. Длиной шага считается
+// ||x_n+1 - x_n||₂, невязкой - ||F(x_n+1)||₂, собственный критерий - шаг мал
+// относительно приближения. Нулевая невязка останавливает итерации всегда.
+func systemStop(stop StopCriteria, step, x []float64, residual, epsilon float64) string {
+	if residual == 0 {
+		return StopExact
+	}
+	stepNorm, xNorm := floats.Norm(step, 2), floats.Norm(x, 2)
+	return stop.done(stepNorm < epsilon*math.Max(1, xNorm), StopStep, stepNorm, xNorm, func(float64) float64 { return residual })
+}
@@ -0,0 +1,96 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func init() {
+	RegisterSystem(SystemMethod{
+		Name:   "newton",
+		Title:  "Метод Ньютона для систем",
+		Params: systemParams,
+		New: func(p Params) (SystemSolver, error) {
+			return NewNewtonSystemCalculator(p.Strings("equations"), p.Strings("vars"), p.Values("params"), p.Vector("x0"), p.Float("epsilon"))
+		},
+	})
+}
+
+// NewtonSystemCalculator реализует метод Ньютона для систем: на каждом шаге
+// решается линейная система J(x_n) * dx = -F(x_n) и x_n+1 = x_n + dx.
+// Сходится квадратично, но требует матрицу Якоби на каждой итерации.
+type NewtonSystemCalculator struct {
+	stopper
+
+	X0      []float64
+	Epsilon float64
+
+	sys *system
+	jac jacobian
+}
+
+func NewNewtonSystemCalculator(equations, vars []string, params map[string]float64, x0 []float64, epsilon float64) (*NewtonSystemCalculator, error) {
+	sys, err := compileSystem(equations, vars, params, x0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NewtonSystemCalculator{
+		X0:      x0,
+		Epsilon: epsilon,
+		sys:     sys,
+		jac:     newJacobian(sys),
+	}, nil
+}
+
+func (c *NewtonSystemCalculator) Calculate(ctx context.Context) (SystemResult, error) {
+	res := SystemResult{Info: c.jac.info()}
+	res.Info["vars"] = c.sys.vars
+	defer func() { res.Info["evaluations"] = c.sys.evaluations }()
+	mt := newMeter(ctx)
+	c.sys.mt = mt
+
+	n := len(c.X0)
+	x := slices.Clone(c.X0)
+	fx := make([]float64, n)
+	c.sys.eval(fx, x)
+	if isBadVector(fx) {
+		return res, fmt.Errorf("ошибка вычисления системы в начальном приближении")
+	}
+
+	for i := 1; i <= c.Stop.maxIter(); i++ {
+		if err := mt.check(); err != nil {
+			return res, err
+		}
+		res.Iterations = i
+
+		rhs := make([]float64, n)
+		floats.ScaleTo(rhs, -1, fx)
+		dx, err := solveLinear(c.jac.at(x), rhs)
+		if err != nil {
+			return res, fmt.Errorf("%w в точке %v", err, x)
+		}
+
+		xNew := make([]float64, n)
+		floats.AddTo(xNew, x, dx)
+		c.sys.eval(fx, xNew)
+		if isBadVector(fx) {
+			return res, fmt.Errorf("ошибка вычисления системы в точке %v", xNew)
+		}
+
+		residual := floats.Norm(fx, 2)
+		res.Steps = record(mt, res.Steps, SystemStep{XPrev: x, XNew: xNew, Residual: residual, StepNorm: floats.Norm(dx, 2)})
+
+		if reason := systemStop(c.Stop, dx, xNew, residual, c.Epsilon); reason != "" {
+			res.Root, res.StopReason = xNew, reason
+			return res, nil
+		}
+		x = xNew
+	}
+
+	res.StopReason = StopMaxIter
+	return res, fmt.Errorf("превышено максимальное количество итераций")
+}
@@ -1,15 +1,21 @@
-import { drawBaseGraph, drawStep, initPlot } from './plot.js';
+import { drawBaseGraph, drawStep, initPlot, setFormulaParams } from './plot.js';
 import { calculateMethod } from './api.js';
 
 // DOM Elements
 const form = document.getElementById('calc-form');
 const methodSelect = document.getElementById('method-select');
 const formulaInput = document.getElementById('formula-input');
+const paramsInput = document.getElementById('params-input');
 const inputA = document.getElementById('input-a');
 const inputB = document.getElementById('input-b');
 const inputX0 = document.getElementById('input-x0');
+const inputX1 = document.getElementById('input-x1');
 const rangeGroup = document.getElementById('range-group');
 const initialGuessGroup = document.getElementById('initial-guess-group');
+const secondGuessGroup = document.getElementById('second-guess-group');
+const iterModeGroup = document.getElementById('iter-mode-group');
+const iterModeSelect = document.getElementById('iter-mode-select');
+const accelSelect = document.getElementById('accel-select');
 const precisionSlider = document.getElementById('precision-slider');
 const precisionValue = document.getElementById('precision-value');
 const btnCalculate = document.getElementById('btn-calculate');
@@ -28,30 +34,114 @@ const resultsBox = document.getElementById('results-box');
 const resRoot = document.getElementById('res-root');
 const resIters = document.getElementById('res-iters');
 const resError = document.getElementById('res-error');
+const resInfo = document.getElementById('res-info');
 
 // Loader
 const plotLoader = document.getElementById('plot-loader');
 
+// Методы, работающие от начального приближения, а не от отрезка [a, b]
+const pointMethods = ['newton', 'simple_iter', 'secant', 'halley', 'chebyshev', 'newton_modified'];
+
+// Подписи для дополнительных сведений, которые возвращают отдельные методы
+const infoLabels = {
+    multiplicity: 'Кратность корня:',
+    multiplicity_estimate: 'Оценка кратности:',
+    derivative: "f'(x) =",
+    second_derivative: "f''(x) =",
+    derivative_mode: 'Производная:',
+    order: 'Порядок сходимости p:',
+    rate: 'Константа C:',
+    verdict: 'Сходимость:',
+    verdict_reason: 'Обоснование:',
+    contraction_interval: 'Отрезок проверки:',
+    contraction_q: "q = max|φ'(x)|:",
+    phi_range: 'φ на отрезке:',
+    maps_into_interval: 'φ переводит отрезок в себя:',
+    apriori_iterations: 'Априорная оценка итераций:',
+    lambda: 'λ:',
+    phi: 'φ(x) =',
+    acceleration: 'Ускорение:',
+    raw_iterations: 'Итераций без ускорения:',
+    raw_converged: 'Без ускорения сходится:',
+    phi_evaluations: 'Вычислений φ:',
+    stop_reason: 'Критерий остановки:',
+};
+
+const infoValues = {
+    converges: 'гарантирована',
+    may_diverge: 'не гарантирована',
+    aitken: 'Эйткен',
+    steffensen: 'Стеффенсен',
+};
+
+// Причины остановки итераций; при нескольких сработавших критериях сервер перечисляет их через "+"
+const stopReasons = {
+    step: '|xₙ₊₁ - xₙ| < ε',
+    bracket: 'длина отрезка < ε',
+    exact: 'f(x) = 0',
+    step_abs: 'абсолютный шаг',
+    step_rel: 'относительный шаг',
+    residual: 'невязка',
+    max_iter: 'предел итераций',
+};
+
+function formatStopReason(reason) {
+    if (!reason) return undefined;
+    return reason.split('+').map(r => stopReasons[r] || r).join(' и ');
+}
+
+function renderInfo(info) {
+    resInfo.innerHTML = '';
+    if (!info) return;
+
+    for (const [key, value] of Object.entries(info)) {
+        if (!['number', 'string', 'boolean'].includes(typeof value)) continue;
+
+        const row = document.createElement('div');
+        row.className = 'flex justify-between items-center gap-2';
+
+        const label = document.createElement('span');
+        label.className = 'text-sm text-gray-500';
+        label.textContent = infoLabels[key] || key;
+
+        const val = document.createElement('span');
+        val.className = 'font-mono text-gray-300 text-xs text-right break-all';
+        if (typeof value === 'boolean') {
+            val.textContent = value ? 'да' : 'нет';
+        } else if (typeof value === 'number' && !Number.isInteger(value)) {
+            val.textContent = value.toFixed(6);
+        } else {
+            val.textContent = infoValues[value] || value;
+        }
+
+        row.append(label, val);
+        resInfo.append(row);
+    }
+}
+
 // State
 let currentSteps = [];
+let currentRawSteps = [];
 let currentStepIndex = 0;
 let isPlaying = false;
 let playInterval = null;
 
 function updateMethodUI() {
     const method = methodSelect.value;
-    if (method === 'newton' || method === 'simple_iter') {
+    if (pointMethods.includes(method)) {
         rangeGroup.classList.add('hidden');
         initialGuessGroup.classList.remove('hidden');
     } else {
         rangeGroup.classList.remove('hidden');
         initialGuessGroup.classList.add('hidden');
     }
+    secondGuessGroup.classList.toggle('hidden', method !== 'secant');
+    iterModeGroup.classList.toggle('hidden', method !== 'simple_iter');
 }
 
 function getGraphCenterAndSpan() {
     const method = methodSelect.value;
-    if (method === 'newton' || method === 'simple_iter') {
+    if (pointMethods.includes(method)) {
         const x0Str = inputX0.value.replace(',', '.');
         const x0 = parseFloat(x0Str) || 2.5;
         // Для Ньютона строим график вокруг начальной точки
@@ -65,8 +155,25 @@ function getGraphCenterAndSpan() {
     }
 }
 
+// parseFormulaParams разбирает строку вида "a=2, b=3" в объект параметров.
+// Возвращает null, если строка записана с ошибкой
+function parseFormulaParams(str) {
+    const params = {};
+    for (const part of str.split(/[;,]/)) {
+        if (!part.trim()) continue;
+        const [name, value, ...rest] = part.split('=').map(s => s.trim());
+        const num = parseFloat((value || '').replace(',', '.'));
+        if (rest.length || !/^[A-Za-z_][A-Za-z0-9_]*$/.test(name) || isNaN(num)) {
+            return null;
+        }
+        params[name] = num;
+    }
+    return params;
+}
+
 function handleBaseGraphUpdate() {
     const expr = formulaInput.value;
+    setFormulaParams(parseFormulaParams(paramsInput.value) || {});
     const { center, span } = getGraphCenterAndSpan();
     drawBaseGraph(expr, center, span);
 }
@@ -84,9 +191,11 @@ const debouncedUpdate = () => {
 };
 
 formulaInput.addEventListener('input', debouncedUpdate);
+paramsInput.addEventListener('input', debouncedUpdate);
 inputA.addEventListener('input', debouncedUpdate);
 inputB.addEventListener('input', debouncedUpdate);
 inputX0.addEventListener('input', debouncedUpdate);
+inputX1.addEventListener('input', debouncedUpdate);
 
 precisionSlider.addEventListener('input', (e) => {
     precisionValue.textContent = `1e-${e.target.value}`;
@@ -110,9 +219,15 @@ btnCalculate.addEventListener('click', async () => {
         return;
     }
 
-    const payload = { formula, epsilon };
+    const params = parseFormulaParams(paramsInput.value);
+    if (params === null) {
+        alert("Параметры формулы нужно записать в виде: a=2, b=3");
+        return;
+    }
+
+    const payload = { formula, epsilon, params };
 
-    if (method === 'dichotomy') {
+    if (!pointMethods.includes(method)) {
         let a = parseFloat(inputA.value.replace(',', '.'));
         let b = parseFloat(inputB.value.replace(',', '.'));
         if (isNaN(a) || isNaN(b)) {
@@ -128,6 +243,18 @@ btnCalculate.addEventListener('click', async () => {
             return;
         }
         payload.x0 = x0;
+        if (method === 'secant') {
+            let x1 = parseFloat(inputX1.value.replace(',', '.'));
+            if (isNaN(x1)) {
+                alert("Пожалуйста, введите второе приближение x1");
+                return;
+            }
+            payload.x1 = x1;
+        }
+        if (method === 'simple_iter') {
+            payload.mode = iterModeSelect.value;
+            payload.acceleration = accelSelect.value;
+        }
     }
 
     plotLoader.classList.remove('hidden');
@@ -157,12 +284,19 @@ btnCalculate.addEventListener('click', async () => {
         drawBaseGraph(formula, newCenter, newSpan);
 
         currentSteps = data.steps;
+        currentRawSteps = data.raw_steps || [];
         currentStepIndex = 0;
         
         resultsBox.classList.remove('hidden');
         resRoot.textContent = data.root.toFixed(6);
         resIters.textContent = data.iterations;
-        resError.textContent = data.error.toExponential(2);
+        resError.textContent = data.error != null ? data.error.toExponential(2) : '—';
+        renderInfo({
+            ...data.info,
+            order: data.diagnostics?.order,
+            rate: data.diagnostics?.rate,
+            stop_reason: formatStopReason(data.stop_reason),
+        });
         
         totalStepsEl.textContent = currentSteps.length;
         currentStepEl.textContent = '1';
@@ -172,7 +306,7 @@ btnCalculate.addEventListener('click', async () => {
         btnNext.disabled = currentSteps.length <= 1;
         btnPlayPause.disabled = currentSteps.length <= 1;
         
-        drawStep(0, currentSteps, method, formula);
+        drawStep(0, currentSteps, method, formula, currentRawSteps);
     } catch (err) {
         alert("Ошибка вычисления: " + err.message);
     } finally {
@@ -183,7 +317,7 @@ btnCalculate.addEventListener('click', async () => {
 function nextStep() {
     if (currentStepIndex < currentSteps.length - 1) {
         currentStepIndex++;
-        drawStep(currentStepIndex, currentSteps, methodSelect.value, formulaInput.value);
+        drawStep(currentStepIndex, currentSteps, methodSelect.value, formulaInput.value, currentRawSteps);
         currentStepEl.textContent = currentStepIndex + 1;
         btnPrev.disabled = false;
         if (currentStepIndex === currentSteps.length - 1) {
@@ -196,7 +330,7 @@ function nextStep() {
 function prevStep() {
     if (currentStepIndex > 0) {
         currentStepIndex--;
-        drawStep(currentStepIndex, currentSteps, methodSelect.value, formulaInput.value);
+        drawStep(currentStepIndex, currentSteps, methodSelect.value, formulaInput.value, currentRawSteps);
         currentStepEl.textContent = currentStepIndex + 1;
         btnNext.disabled = false;
         if (currentStepIndex === 0) {
@@ -211,7 +345,7 @@ function togglePlayback() {
     } else {
         if (currentStepIndex === currentSteps.length - 1) {
             currentStepIndex = 0;
-            drawStep(0, currentSteps, methodSelect.value, formulaInput.value);
+            drawStep(0, currentSteps, methodSelect.value, formulaInput.value, currentRawSteps);
             currentStepEl.textContent = 1;
         }
         isPlaying = true;
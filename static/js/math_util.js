@@ -1,20 +1,268 @@
-export function evaluateMathStr(expr, x) {
-    try {
-        let parts = expr.split('=');
-        let jsExpr = parts[0].trim();
-        if (parts.length === 2) {
-            let right = parts[1].trim();
-            if (right !== '0') {
-                jsExpr = `(${jsExpr}) - (${right})`;
+// Разбор формул на клиенте. Грамматика полностью совпадает с серверным
+// парсером (pkg/math/mathutils): те же операторы, приоритеты, неявное
+// умножение и набор функций, поэтому график строится ровно по той функции,
+// которую решает сервер.
+
+// Встроенные функции одного аргумента
+const functions1 = {
+    ln: Math.log,
+    log: Math.log10,
+    sin: Math.sin,
+    cos: Math.cos,
+    tan: Math.tan,
+    cot: x => 1 / Math.tan(x),
+    sec: x => 1 / Math.cos(x),
+    asin: Math.asin,
+    acos: Math.acos,
+    atan: Math.atan,
+    sinh: Math.sinh,
+    cosh: Math.cosh,
+    tanh: Math.tanh,
+    sqrt: Math.sqrt,
+    cbrt: Math.cbrt,
+    abs: Math.abs,
+    exp: Math.exp,
+    sign: x => (x > 0 ? 1 : x < 0 ? -1 : x),
+    floor: Math.floor,
+    ceil: Math.ceil,
+};
+
+// Встроенные функции двух аргументов
+const functions2 = {
+    log: (x, base) => Math.log(x) / Math.log(base),
+    pow: Math.pow,
+    min: Math.min,
+    max: Math.max,
+};
+
+const constants = { pi: Math.PI, e: Math.E };
+
+// Силы связывания операторов (как в parser.go)
+const BP_NONE = 0;
+const BP_ADD = 10;
+const BP_MUL = 20;
+const BP_PREFIX = 25;
+const BP_POW = 30;
+const BP_ARG = 50;
+
+const infixBP = { '+': BP_ADD, '-': BP_ADD, '*': BP_MUL, '/': BP_MUL, '^': BP_POW };
+
+function isFunction(name) {
+    return name in functions1 || name in functions2;
+}
+
+function tokenize(input) {
+    // Как и lexer.go, идем по кодовым точкам, а не по UTF-16 единицам
+    const src = Array.from(input);
+    const tokens = [];
+    let i = 0;
+    while (i < src.length) {
+        const ch = src[i];
+        if (/\s/.test(ch)) {
+            i++;
+        } else if (/[0-9.]/.test(ch)) {
+            const start = i;
+            while (i < src.length && /[0-9.]/.test(src[i])) i++;
+            // Экспоненциальная запись: 1e-3. Одинокая "e" после числа к числу не относится.
+            if (i < src.length && (src[i] === 'e' || src[i] === 'E')) {
+                let j = i + 1;
+                if (j < src.length && (src[j] === '+' || src[j] === '-')) j++;
+                if (j < src.length && /[0-9]/.test(src[j])) {
+                    while (j < src.length && /[0-9]/.test(src[j])) j++;
+                    i = j;
+                }
+            }
+            const text = src.slice(start, i).join('');
+            if (!/^(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$/.test(text)) {
+                throw new Error(`некорректное число "${text}"`);
+            }
+            tokens.push({ kind: 'num', value: parseFloat(text), pos: start });
+        } else if (/[\p{L}_]/u.test(ch)) {
+            // Тот же класс, что в lexer.go: unicode.IsLetter, '_' и unicode.IsDigit (Nd)
+            const start = i;
+            while (i < src.length && /[\p{L}\p{Nd}_]/u.test(src[i])) i++;
+            tokens.push({ kind: 'ident', text: src.slice(start, i).join(''), pos: start });
+        } else if (ch === '*' && src[i + 1] === '*') {
+            tokens.push({ kind: 'op', text: '^', pos: i });
+            i += 2;
+        } else if ('+-*/^'.includes(ch)) {
+            tokens.push({ kind: 'op', text: ch, pos: i++ });
+        } else if (ch === '(' || ch === ')' || ch === ',' || ch === '=') {
+            tokens.push({ kind: ch, text: ch, pos: i++ });
+        } else {
+            throw new Error(`недопустимый символ "${ch}"`);
+        }
+    }
+    tokens.push({ kind: 'eof', pos: src.length });
+    return tokens;
+}
+
+class Parser {
+    constructor(tokens) {
+        this.tokens = tokens;
+        this.i = 0;
+    }
+
+    peek() {
+        return this.tokens[this.i];
+    }
+
+    next() {
+        const t = this.tokens[this.i];
+        if (t.kind !== 'eof') this.i++;
+        return t;
+    }
+
+    parseExpr(minBP) {
+        let left = this.parsePrefix();
+        for (;;) {
+            const t = this.peek();
+            let op;
+            if (t.kind === 'op') {
+                op = t.text;
+            } else if (t.kind === 'ident' || t.kind === '(') {
+                // Неявное умножение: 2x, 3sin(x), x(x-1), (x+1)(x-1)
+                op = '*';
+            } else {
+                return left;
+            }
+
+            const bp = infixBP[op];
+            if (bp <= minBP) return left;
+            if (t.kind === 'op') this.next();
+
+            // Степень правоассоциативна
+            const right = this.parseExpr(op === '^' ? bp - 1 : bp);
+            left = { type: 'binary', op, l: left, r: right };
+        }
+    }
+
+    parsePrefix() {
+        const t = this.next();
+        switch (t.kind) {
+            case 'num':
+                return { type: 'num', value: t.value };
+            case 'ident':
+                if (isFunction(t.text)) return this.parseCall(t);
+                return { type: 'var', name: t.text };
+            case '(': {
+                const node = this.parseExpr(BP_NONE);
+                if (this.next().kind !== ')') throw new Error("ожидалась закрывающая скобка ')'");
+                return node;
+            }
+            case 'op':
+                if (t.text === '-') return { type: 'neg', x: this.parseExpr(BP_PREFIX) };
+                if (t.text === '+') return this.parseExpr(BP_PREFIX);
+                break;
+            case 'eof':
+                throw new Error('ожидалось выражение');
+        }
+        throw new Error(`неожиданная лексема "${t.text}"`);
+    }
+
+    parseCall(name) {
+        if (this.peek().kind !== '(') {
+            return { type: 'call', name: name.text, args: [this.parseExpr(BP_ARG)] };
+        }
+        this.next();
+
+        const args = [];
+        if (this.peek().kind !== ')') {
+            for (;;) {
+                args.push(this.parseExpr(BP_NONE));
+                if (this.peek().kind !== ',') break;
+                this.next();
+            }
+        }
+        if (this.next().kind !== ')') throw new Error("ожидалась закрывающая скобка ')' после аргументов функции");
+
+        const ok = (args.length === 1 && name.text in functions1) || (args.length === 2 && name.text in functions2);
+        if (!ok) throw new Error(`функция ${name.text}: неверное число аргументов`);
+        return { type: 'call', name: name.text, args };
+    }
+}
+
+// parseFormula разбирает формулу; уравнение "A = B" приводится к "A - (B)"
+function parseFormula(src) {
+    const p = new Parser(tokenize(src));
+    let node = p.parseExpr(BP_NONE);
+    if (p.peek().kind === '=') {
+        p.next();
+        const right = p.parseExpr(BP_NONE);
+        if (!(right.type === 'num' && right.value === 0)) {
+            node = { type: 'binary', op: '-', l: node, r: right };
+        }
+    }
+    if (p.peek().kind !== 'eof') throw new Error(`неожиданная лексема "${p.peek().text}"`);
+    return node;
+}
+
+// compileNode превращает дерево в замыкание от объекта со значениями переменных
+function compileNode(node) {
+    switch (node.type) {
+        case 'num': {
+            const v = node.value;
+            return () => v;
+        }
+        case 'var': {
+            const name = node.name;
+            // Константы, как и в compile.go, параметрами не переопределяются
+            if (name in constants) {
+                const c = constants[name];
+                return () => c;
+            }
+            return vars => {
+                if (!(name in vars)) throw new Error(`неизвестная переменная "${name}"`);
+                return vars[name];
+            };
+        }
+        case 'neg': {
+            const x = compileNode(node.x);
+            return vars => -x(vars);
+        }
+        case 'binary': {
+            const l = compileNode(node.l);
+            const r = compileNode(node.r);
+            switch (node.op) {
+                case '+': return vars => l(vars) + r(vars);
+                case '-': return vars => l(vars) - r(vars);
+                case '*': return vars => l(vars) * r(vars);
+                case '/': return vars => l(vars) / r(vars);
+                case '^': return vars => Math.pow(l(vars), r(vars));
+            }
+            break;
+        }
+        case 'call': {
+            const args = node.args.map(compileNode);
+            if (args.length === 2) {
+                const fn = functions2[node.name];
+                return vars => fn(args[0](vars), args[1](vars));
             }
+            const fn = functions1[node.name];
+            const a = args[0];
+            return vars => fn(a(vars));
         }
+    }
+    throw new Error('неизвестный узел выражения');
+}
 
-        jsExpr = jsExpr.replace(/\^/g, '**');
-        jsExpr = jsExpr.replace(/(ln|log|sin|cos|tan|sqrt|abs|exp)\s+([a-zA-Z0-9_\.]+)/g, '$1($2)');
-        jsExpr = jsExpr.replace(/\bln\b/g, 'log');
-        jsExpr = jsExpr.replace(/sin|cos|tan|log|exp|sqrt|abs/g, match => `Math.${match}`);
-        
-        return new Function('x', `return ${jsExpr}`)(x);
+// Кэш скомпилированных формул: график вычисляет одну формулу сотни раз подряд
+const cache = new Map();
+
+// compileFormula возвращает функцию vars => значение или бросает ошибку разбора
+export function compileFormula(expr) {
+    let fn = cache.get(expr);
+    if (!fn) {
+        fn = compileNode(parseFormula(expr));
+        if (cache.size > 100) cache.clear();
+        cache.set(expr, fn);
+    }
+    return fn;
+}
+
+export function evaluateMathStr(expr, x, params = {}) {
+    try {
+        return compileFormula(expr)({ ...params, x });
     } catch (e) {
         return null;
     }
@@ -1,9 +1,18 @@
 import { evaluateMathStr } from './math_util.js';
 
 let currentBaseTrace = null;
+let formulaParams = {};
 let currentXRange = [-10, 10];
 let currentYRange = [-10, 10];
 
+// Цвета подшагов комбинированных методов (Брент, Риддерс)
+const stepKindColors = {
+    bisection: '#ff3366',
+    secant: '#7000ff',
+    iqi: '#ffb800',
+    ridders: '#00ff9d',
+};
+
 const layoutTemplate = {
     paper_bgcolor: 'rgba(0,0,0,0)',
     plot_bgcolor: 'rgba(0,0,0,0)',
@@ -24,6 +33,11 @@ const layoutTemplate = {
     dragmode: 'pan'
 };
 
+// setFormulaParams задает значения параметров формулы (a=2, b=3) для построения графика
+export function setFormulaParams(params) {
+    formulaParams = params;
+}
+
 export function initPlot() {
     Plotly.newPlot('plot', [{
         x: [], y: [], type: 'scatter', mode: 'lines',
@@ -59,7 +73,7 @@ export function drawBaseGraph(expr, center, span = 10) {
     let maxY = -Infinity;
 
     for (let x = a; x <= b; x += step) {
-        let y = evaluateMathStr(expr, x);
+        let y = evaluateMathStr(expr, x, formulaParams);
         if (y !== null && !isNaN(y) && Math.abs(y) < 100000) {
             xVals.push(x);
             yVals.push(y);
@@ -90,16 +104,18 @@ export function drawBaseGraph(expr, center, span = 10) {
     Plotly.react('plot', [currentBaseTrace], layout);
 }
 
-export function drawStep(index, steps, method, expr) {
+// rawSteps - шаги без ускорения сходимости; если заданы, рисуются рядом с ускоренными
+export function drawStep(index, steps, method, expr, rawSteps = []) {
     if (index < 0 || index >= steps.length) return;
     
     const stepData = steps[index];
     let stepTraces = [];
     
-    if (method === 'dichotomy') {
+    if (method === 'dichotomy' || method === 'brent' || method === 'ridders') {
         const a = stepData.a !== undefined ? stepData.a : stepData.A;
         const b = stepData.b !== undefined ? stepData.b : stepData.B;
         const c = stepData.c !== undefined ? stepData.c : stepData.C;
+        const pointColor = stepKindColors[stepData.kind] || '#00f0ff';
 
         stepTraces.push({
             x: [a, a], y: [currentYRange[0], currentYRange[1]], 
@@ -111,9 +127,9 @@ export function drawStep(index, steps, method, expr) {
         });
         stepTraces.push({
             x: [c], y: [0], 
-            mode: 'markers', name: 'c (mid)', marker: { color: '#00f0ff', size: 10, symbol: 'circle-dot' }
+            mode: 'markers', name: stepData.kind || 'c (mid)', marker: { color: pointColor, size: 10, symbol: 'circle-dot' }
         });
-    } else if (method === 'newton') {
+    } else if (['newton', 'halley', 'chebyshev', 'newton_modified'].includes(method)) {
         const x_p = stepData.x_prev !== undefined ? stepData.x_prev : stepData.XPrev;
         const fx = stepData.fx !== undefined ? stepData.fx : stepData.Fx;
         const x_n = stepData.x_new !== undefined ? stepData.x_new : stepData.XNew;
@@ -127,23 +143,67 @@ export function drawStep(index, steps, method, expr) {
             mode: 'markers', name: 'Points', marker: { color: ['#ffffff', '#00f0ff'], size: 8 }
         });
         stepTraces.push({
-            x: [x_n, x_n], y: [0, evaluateMathStr(expr, x_n)], 
+            x: [x_n, x_n], y: [0, evaluateMathStr(expr, x_n, formulaParams)], 
             mode: 'lines', name: 'Projection', line: { color: 'rgba(255,255,255,0.3)', width: 1, dash: 'dot' }
         });
     } else if (method === 'simple_iter') {
         const x_p = stepData.x_prev !== undefined ? stepData.x_prev : stepData.XPrev;
         const x_n = stepData.x_new !== undefined ? stepData.x_new : stepData.XNew;
-        let fx = stepData.fx !== undefined ? stepData.fx : stepData.Fx;
-        if (fx === undefined) fx = evaluateMathStr(expr, x_p);
+        // fx простой итерации - длина шага |x_n+1 - x_n|, поэтому точки берем с графика
+        const fx = evaluateMathStr(expr, x_p, formulaParams);
         
         stepTraces.push({
-            x: [x_p, x_n], y: [fx, evaluateMathStr(expr, x_n)], 
+            x: [x_p, x_n], y: [fx, evaluateMathStr(expr, x_n, formulaParams)], 
             mode: 'lines', name: 'Iteration path', line: { color: '#7000ff', width: 2, dash: 'dot' }
         });
         stepTraces.push({
-            x: [x_p, x_n], y: [fx, evaluateMathStr(expr, x_n)], 
+            x: [x_p, x_n], y: [fx, evaluateMathStr(expr, x_n, formulaParams)], 
             mode: 'markers', name: 'Points', marker: { color: ['#ffffff', '#00f0ff'], size: 8 }
         });
+
+        // Обычная итерация на том же номере шага - для сравнения с ускоренной
+        if (rawSteps.length > 0) {
+            const raw = rawSteps[Math.min(index, rawSteps.length - 1)];
+            stepTraces.push({
+                x: [raw.x_prev, raw.x_new], y: [evaluateMathStr(expr, raw.x_prev, formulaParams), evaluateMathStr(expr, raw.x_new, formulaParams)],
+                mode: 'lines+markers', name: 'Без ускорения',
+                line: { color: '#ff9f1c', width: 1.5, dash: 'dot' }, marker: { color: '#ff9f1c', size: 6 }
+            });
+        }
+    } else if (method === 'secant' || method === 'chord') {
+        // Хорда через точки (a, f(a)) и (b, f(b)), пересекающая ось в точке c
+        const a = stepData.a;
+        const b = stepData.b;
+        const c = stepData.c !== undefined ? stepData.c : stepData.x_new;
+        const fa = evaluateMathStr(expr, a, formulaParams);
+        const fb = evaluateMathStr(expr, b, formulaParams);
+
+        if (method === 'chord') {
+            stepTraces.push({
+                x: [a, a], y: [currentYRange[0], currentYRange[1]], 
+                mode: 'lines', name: 'a', line: { color: '#ff3366', width: 2, dash: 'dash' }
+            });
+            stepTraces.push({
+                x: [b, b], y: [currentYRange[0], currentYRange[1]], 
+                mode: 'lines', name: 'b', line: { color: '#ff3366', width: 2, dash: 'dash' }
+            });
+        }
+
+        // Для секущей точка c может лежать вне [a, b], поэтому продлеваем хорду до оси
+        const chordX = [a, b, c].sort((p, q) => p - q);
+        const slope = (fb - fa) / (b - a);
+        stepTraces.push({
+            x: chordX, y: chordX.map(x => fa + slope * (x - a)), 
+            mode: 'lines', name: 'Chord', line: { color: '#7000ff', width: 2 }
+        });
+        stepTraces.push({
+            x: [a, b, c], y: [fa, fb, 0], 
+            mode: 'markers', name: 'Points', marker: { color: ['#ffffff', '#ffffff', '#00f0ff'], size: 8 }
+        });
+        stepTraces.push({
+            x: [c, c], y: [0, evaluateMathStr(expr, c, formulaParams)], 
+            mode: 'lines', name: 'Projection', line: { color: 'rgba(255,255,255,0.3)', width: 1, dash: 'dot' }
+        });
     }
     
     // Rigidly Fix Layout X and Y ranges so plot doesn't zoom